@@ -17,11 +17,13 @@ import (
 
 	_ "github.com/PoorMercymain/filmoteka/docs"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/config"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/handlers"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/middleware"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/repository"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/service"
 	"github.com/PoorMercymain/filmoteka/pkg/logger"
+	ratelimiter "github.com/PoorMercymain/filmoteka/pkg/rate-limiter"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -38,9 +40,15 @@ import (
 // @Tag.name Films
 // @Tag.description Группа запросов для управления списком фильмов
 
+// @Tag.name Genres
+// @Tag.description Группа запросов для управления списком жанров
+
 // @Tag.name Auth
 // @Tag.description Группа запросов для авторизации
 
+// @Tag.name Users
+// @Tag.description Группа запросов для управления пользователями и их ролями
+
 // @Schemes http
 
 func main() {
@@ -51,6 +59,11 @@ func main() {
 
 	logger.SetLogFile(cfg.LogFilePath)
 
+	credentialsPolicy, err := cfg.CredentialsPolicy()
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
+
 	m, err := migrate.New("file://"+cfg.MigrationsPath, cfg.DSN())
 	if err != nil {
 		logger.Logger().Fatalln(zap.Error(err))
@@ -73,26 +86,71 @@ func main() {
 	aur := repository.NewAuthorization(repository.NewPostgres(pool))
 	ar := repository.NewActor(repository.NewPostgres(pool))
 	fr := repository.NewFilm(repository.NewPostgres(pool))
-	aus := service.NewAuthorization(aur)
+	gr := repository.NewGenre(repository.NewPostgres(pool))
+	aus := service.NewAuthorization(aur, credentialsPolicy, cfg.LoginThrottlePolicy())
 	as := service.NewActor(ar)
 	fs := service.NewFilm(fr)
-	auh := handlers.NewAuthorization(aus, cfg.JWTKey)
-	ah := handlers.NewActor(as)
-	fh := handlers.NewFilm(fs)
+	gs := service.NewGenre(gr)
+	auh := handlers.NewAuthorization(aus, cfg.JWTKey, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.PasswordResetTokenTTL)
+	ah := handlers.NewActor(as, float64(cfg.SimilarityThreshold))
+	fh := handlers.NewFilm(fs, float64(cfg.SimilarityThreshold))
+	gh := handlers.NewGenre(gs)
+
+	rateLimitStore := ratelimiter.NewMemoryStore()
+	rateLimit := func(route string, next http.Handler) http.Handler {
+		return middleware.RateLimit(next, route, cfg.RouteRateLimit(route), rateLimitStore, cfg.JWTKey)
+	}
 
 	mux := http.NewServeMux()
 
-	mux.Handle("POST /actor", middleware.Log(middleware.AdminRequired(http.HandlerFunc(ah.CreateActor), auh.JWTKey)))
-	mux.Handle("PUT /actor/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(ah.UpdateActor), auh.JWTKey)))
-	mux.Handle("DELETE /actor/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(ah.DeleteActor), auh.JWTKey)))
-	mux.Handle("POST /film", middleware.Log(middleware.AdminRequired(http.HandlerFunc(fh.CreateFilm), auh.JWTKey)))
-	mux.Handle("PUT /film/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(fh.UpdateFilm), auh.JWTKey)))
-	mux.Handle("DELETE /film/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(fh.DeleteFilm), auh.JWTKey)))
-	mux.Handle("GET /films", middleware.Log(middleware.AuthorizationRequired(http.HandlerFunc(fh.ReadFilms), auh.JWTKey)))
-	mux.Handle("GET /films/search", middleware.Log(middleware.AuthorizationRequired(http.HandlerFunc(fh.FindFilms), auh.JWTKey)))
-	mux.Handle("GET /actors", middleware.Log(middleware.AuthorizationRequired(http.HandlerFunc(ah.ReadActors), auh.JWTKey)))
-	mux.Handle("POST /register", middleware.Log(http.HandlerFunc(auh.Register)))
-	mux.Handle("POST /login", middleware.Log(http.HandlerFunc(auh.LogIn)))
+	mux.Handle("POST /actor", middleware.Log(rateLimit("POST /actor", middleware.RequirePermission(http.HandlerFunc(ah.CreateActor), domain.PermissionActorCreate, auh.JWTKey, aus))))
+	mux.Handle("PUT /actor/{id}", middleware.Log(rateLimit("PUT /actor/{id}", middleware.RequirePermission(http.HandlerFunc(ah.UpdateActor), domain.PermissionActorUpdate, auh.JWTKey, aus))))
+	mux.Handle("PATCH /actor/{id}", middleware.Log(rateLimit("PATCH /actor/{id}", middleware.RequirePermission(http.HandlerFunc(ah.PatchActor), domain.PermissionActorUpdate, auh.JWTKey, aus))))
+	mux.Handle("DELETE /actor/{id}", middleware.Log(rateLimit("DELETE /actor/{id}", middleware.RequirePermission(http.HandlerFunc(ah.DeleteActor), domain.PermissionActorDelete, auh.JWTKey, aus))))
+	mux.Handle("POST /actor/{id}/restore", middleware.Log(rateLimit("POST /actor/{id}/restore", middleware.RequirePermission(http.HandlerFunc(ah.RestoreActor), domain.PermissionActorDelete, auh.JWTKey, aus))))
+	mux.Handle("DELETE /actor/{id}/purge", middleware.Log(rateLimit("DELETE /actor/{id}/purge", middleware.RequirePermission(http.HandlerFunc(ah.PurgeActor), domain.PermissionActorDelete, auh.JWTKey, aus))))
+	mux.Handle("GET /actors/deleted", middleware.Log(rateLimit("GET /actors/deleted", middleware.RequirePermission(http.HandlerFunc(ah.ReadDeletedActors), domain.PermissionActorDelete, auh.JWTKey, aus))))
+	mux.Handle("GET /actor/{id}/history", middleware.Log(rateLimit("GET /actor/{id}/history", middleware.RequirePermission(http.HandlerFunc(ah.ReadActorHistory), domain.PermissionAuditRead, auh.JWTKey, aus))))
+	mux.Handle("POST /actor/{id}/revert/{revision}", middleware.Log(rateLimit("POST /actor/{id}/revert/{revision}", middleware.RequirePermission(http.HandlerFunc(ah.RevertActor), domain.PermissionActorUpdate, auh.JWTKey, aus))))
+	mux.Handle("POST /film", middleware.Log(rateLimit("POST /film", middleware.RequirePermission(http.HandlerFunc(fh.CreateFilm), domain.PermissionFilmCreate, auh.JWTKey, aus))))
+	mux.Handle("PUT /film/{id}", middleware.Log(rateLimit("PUT /film/{id}", middleware.RequirePermission(http.HandlerFunc(fh.UpdateFilm), domain.PermissionFilmUpdate, auh.JWTKey, aus))))
+	mux.Handle("PATCH /film/{id}", middleware.Log(rateLimit("PATCH /film/{id}", middleware.RequirePermission(http.HandlerFunc(fh.PatchFilm), domain.PermissionFilmUpdate, auh.JWTKey, aus))))
+	mux.Handle("DELETE /film/{id}", middleware.Log(rateLimit("DELETE /film/{id}", middleware.RequirePermission(http.HandlerFunc(fh.DeleteFilm), domain.PermissionFilmDelete, auh.JWTKey, aus))))
+	mux.Handle("POST /film/{id}/restore", middleware.Log(rateLimit("POST /film/{id}/restore", middleware.RequirePermission(http.HandlerFunc(fh.RestoreFilm), domain.PermissionFilmDelete, auh.JWTKey, aus))))
+	mux.Handle("DELETE /film/{id}/purge", middleware.Log(rateLimit("DELETE /film/{id}/purge", middleware.RequirePermission(http.HandlerFunc(fh.PurgeFilm), domain.PermissionFilmDelete, auh.JWTKey, aus))))
+	mux.Handle("GET /films/deleted", middleware.Log(rateLimit("GET /films/deleted", middleware.RequirePermission(http.HandlerFunc(fh.ReadDeletedFilms), domain.PermissionFilmDelete, auh.JWTKey, aus))))
+	mux.Handle("GET /film/{id}/history", middleware.Log(rateLimit("GET /film/{id}/history", middleware.RequirePermission(http.HandlerFunc(fh.ReadFilmHistory), domain.PermissionAuditRead, auh.JWTKey, aus))))
+	mux.Handle("POST /film/{id}/revert/{revision}", middleware.Log(rateLimit("POST /film/{id}/revert/{revision}", middleware.RequirePermission(http.HandlerFunc(fh.RevertFilm), domain.PermissionFilmUpdate, auh.JWTKey, aus))))
+	mux.Handle("POST /film/{id}/crew", middleware.Log(rateLimit("POST /film/{id}/crew", middleware.RequirePermission(http.HandlerFunc(fh.AddCrewCredit), domain.PermissionFilmUpdate, auh.JWTKey, aus))))
+	mux.Handle("DELETE /film/{id}/crew/{personID}/{job}", middleware.Log(rateLimit("DELETE /film/{id}/crew/{personID}/{job}", middleware.RequirePermission(http.HandlerFunc(fh.DeleteCrewCredit), domain.PermissionFilmUpdate, auh.JWTKey, aus))))
+	mux.Handle("GET /films", middleware.Log(rateLimit("GET /films", middleware.RequirePermission(http.HandlerFunc(fh.ReadFilms), domain.PermissionFilmRead, auh.JWTKey, aus))))
+	mux.Handle("GET /films/search", middleware.Log(rateLimit("GET /films/search", middleware.RequirePermission(http.HandlerFunc(fh.FindFilms), domain.PermissionFilmRead, auh.JWTKey, aus))))
+	mux.Handle("GET /film/{id}", middleware.Log(rateLimit("GET /film/{id}", middleware.RequirePermission(http.HandlerFunc(fh.ReadFilm), domain.PermissionFilmRead, auh.JWTKey, aus))))
+	mux.Handle("GET /actor/{id}", middleware.Log(rateLimit("GET /actor/{id}", middleware.RequirePermission(http.HandlerFunc(ah.ReadActor), domain.PermissionActorRead, auh.JWTKey, aus))))
+	mux.Handle("GET /actors", middleware.Log(rateLimit("GET /actors", middleware.RequirePermission(http.HandlerFunc(ah.ReadActors), domain.PermissionActorRead, auh.JWTKey, aus))))
+	mux.Handle("GET /actors/search", middleware.Log(rateLimit("GET /actors/search", middleware.RequirePermission(http.HandlerFunc(ah.FindActors), domain.PermissionActorRead, auh.JWTKey, aus))))
+	mux.Handle("POST /genre", middleware.Log(rateLimit("POST /genre", middleware.RequirePermission(http.HandlerFunc(gh.CreateGenre), domain.PermissionGenreCreate, auh.JWTKey, aus))))
+	mux.Handle("PUT /genre/{id}", middleware.Log(rateLimit("PUT /genre/{id}", middleware.RequirePermission(http.HandlerFunc(gh.UpdateGenre), domain.PermissionGenreUpdate, auh.JWTKey, aus))))
+	mux.Handle("DELETE /genre/{id}", middleware.Log(rateLimit("DELETE /genre/{id}", middleware.RequirePermission(http.HandlerFunc(gh.DeleteGenre), domain.PermissionGenreDelete, auh.JWTKey, aus))))
+	mux.Handle("GET /genres", middleware.Log(rateLimit("GET /genres", middleware.RequirePermission(http.HandlerFunc(gh.ReadGenres), domain.PermissionGenreRead, auh.JWTKey, aus))))
+	mux.Handle("POST /register", middleware.Log(rateLimit("POST /register", http.HandlerFunc(auh.Register))))
+	mux.Handle("POST /login", middleware.Log(rateLimit("POST /login", http.HandlerFunc(auh.LogIn))))
+	mux.Handle("POST /token/refresh", middleware.Log(rateLimit("POST /token/refresh", http.HandlerFunc(auh.RefreshToken))))
+	mux.Handle("POST /logout", middleware.Log(rateLimit("POST /logout", http.HandlerFunc(auh.LogOut))))
+	mux.Handle("POST /password", middleware.Log(rateLimit("POST /password", http.HandlerFunc(auh.ChangePassword))))
+	mux.Handle("POST /password/reset", middleware.Log(rateLimit("POST /password/reset", http.HandlerFunc(auh.ResetPassword))))
+	mux.Handle("GET /users", middleware.Log(rateLimit("GET /users", middleware.RequirePermission(http.HandlerFunc(auh.ReadUsers), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("GET /roles", middleware.Log(rateLimit("GET /roles", middleware.RequirePermission(http.HandlerFunc(auh.ReadRoles), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("PUT /user/{login}/role", middleware.Log(rateLimit("PUT /user/{login}/role", middleware.RequirePermission(http.HandlerFunc(auh.SetRole), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("POST /user/{login}/password/reset", middleware.Log(rateLimit("POST /user/{login}/password/reset", middleware.RequirePermission(http.HandlerFunc(auh.CreatePasswordResetToken), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("POST /user/{login}/disable", middleware.Log(rateLimit("POST /user/{login}/disable", middleware.RequirePermission(http.HandlerFunc(auh.DisableUser), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("POST /user/{login}/enable", middleware.Log(rateLimit("POST /user/{login}/enable", middleware.RequirePermission(http.HandlerFunc(auh.EnableUser), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("DELETE /user/{login}", middleware.Log(rateLimit("DELETE /user/{login}", middleware.RequirePermission(http.HandlerFunc(auh.DeleteUser), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("GET /lockouts", middleware.Log(rateLimit("GET /lockouts", middleware.RequirePermission(http.HandlerFunc(auh.ReadLockouts), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("POST /user/{login}/unlock", middleware.Log(rateLimit("POST /user/{login}/unlock", middleware.RequirePermission(http.HandlerFunc(auh.Unlock), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("POST /apikey", middleware.Log(rateLimit("POST /apikey", middleware.RequirePermission(http.HandlerFunc(auh.CreateAPIKey), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("GET /apikeys", middleware.Log(rateLimit("GET /apikeys", middleware.RequirePermission(http.HandlerFunc(auh.ReadAPIKeys), domain.PermissionUserManage, auh.JWTKey, aus))))
+	mux.Handle("DELETE /apikey/{id}", middleware.Log(rateLimit("DELETE /apikey/{id}", middleware.RequirePermission(http.HandlerFunc(auh.RevokeAPIKey), domain.PermissionUserManage, auh.JWTKey, aus))))
 	mux.Handle("/swagger/*", httpSwagger.WrapHandler)
 
 	server := &http.Server{
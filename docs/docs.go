@@ -59,8 +59,80 @@ const docTemplate = `{
             }
         },
         "/actor/{id}": {
+            "get": {
+                "description": "Запрос для получения информации об актере из БД вместе со списком фильмов с его участием",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actors"
+                ],
+                "summary": "Запрос получения актера из БД",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id актера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия актера для If-Match, также используется в If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия актера для If-Match, также используется в If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время последнего изменения"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
             "put": {
-                "description": "Запрос для обновления информации об актере в БД, как полностью, так и частичного",
+                "description": "Запрос для замены информации об актере в БД, все поля обязательны (для частичного обновления используется PATCH)",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,6 +157,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -103,13 +182,19 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             },
             "delete": {
-                "description": "Запрос для удаления информации об актере из БД",
+                "description": "Запрос для удаления актера в корзину, откуда его можно восстановить или удалить окончательно",
                 "tags": [
                     "Actors"
                 ],
@@ -122,6 +207,75 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Запрос для частичного обновления информации об актере в БД, тело запроса - JSON Merge Patch (RFC 7396): отсутствующие поля не изменяются, null для полей актера недопустим",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actors"
+                ],
+                "summary": "Запрос частичного обновления актера в БД",
+                "parameters": [
+                    {
+                        "description": "изменяемые поля актера",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Actor"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id актера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -140,23 +294,37 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/actors": {
+        "/actor/{id}/history": {
             "get": {
-                "description": "Запрос для получения списка актеров из БД, для каждого актера также выводится список фильмов с его участием, предусмотрена пагинация",
+                "description": "Запрос для получения журнала изменений актера, начиная с последних: кто и когда создал, изменил, удалил или восстановил запись и ее состояние до и после изменения. История сохраняется и после окончательного удаления",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Actors"
                 ],
-                "summary": "Запрос получения списка актеров из БД",
+                "summary": "Запрос получения истории изменений актера",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id актера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "example": 1,
@@ -164,17 +332,33 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "description": "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
                     },
                     "204": {
                         "description": "No Content"
@@ -185,39 +369,35 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/film": {
-            "post": {
-                "description": "Запрос для добавления информации о фильме в БД",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/actor/{id}/purge": {
+            "delete": {
+                "description": "Запрос для окончательного удаления актера из корзины вместе со всеми его связями, удалить можно только актера из корзины",
                 "tags": [
-                    "Films"
+                    "Actors"
                 ],
-                "summary": "Запрос добавления информации о фильме в БД",
+                "summary": "Запрос окончательного удаления актера",
                 "parameters": [
                     {
-                        "description": "информация о фильме",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Film"
-                        }
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id актера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -237,30 +417,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/film/{id}": {
-            "put": {
-                "description": "Запрос для обновления информации о фильме, как полного, так и частичного",
-                "consumes": [
-                    "application/json"
-                ],
+        "/actor/{id}/restore": {
+            "post": {
+                "description": "Запрос для восстановления актера из корзины, связи между фильмами и актерами при удалении сохраняются, поэтому восстанавливаются вместе с ним",
                 "tags": [
-                    "Films"
+                    "Actors"
                 ],
-                "summary": "Запрос обновления информации о фильме",
+                "summary": "Запрос восстановления удаленного актера",
                 "parameters": [
-                    {
-                        "description": "информация о фильме",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Film"
-                        }
-                    },
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "id фильма",
+                        "description": "id актера",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -286,21 +454,38 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
-                "description": "Запрос для удаления информации о фильме из БД",
+            }
+        },
+        "/actor/{id}/revert/{revision}": {
+            "post": {
+                "description": "Запрос для возврата актера к состоянию после изменения из истории (revision - id записи из /actor/{id}/history). Если вернуть состояние нельзя (например, актер родился бы позже выхода фильма, в котором он снимался), возвращается 409",
                 "tags": [
-                    "Films"
+                    "Actors"
                 ],
-                "summary": "Запрос удаления фильма из БД",
+                "summary": "Запрос возврата актера к предыдущей версии",
                 "parameters": [
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "id фильма",
+                        "description": "id актера",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id записи истории изменений",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -319,34 +504,43 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/films": {
+        "/actors": {
             "get": {
-                "description": "Запрос для получения списка фильмов из БД, для каждого фильма также выводится список фильмов с его участием, предусмотрена пагинация, по умолчанию сортируется по убыванию рейтинга",
+                "description": "Запрос для получения списка актеров из БД, для каждого актера также выводится список фильмов с его участием, предусмотрена сортировка и пагинация",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Films"
+                    "Actors"
                 ],
-                "summary": "Запрос получения списка фильмов из БД",
+                "summary": "Запрос получения списка актеров из БД",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "title",
-                        "description": "поле для сортировки (release_date, rating, title, по умолчанию - rating)",
+                        "example": "birthday",
+                        "description": "поле для сортировки, возможные значения: name, birthday, film_count (по умолчанию name)",
                         "name": "field",
                         "in": "query"
                     },
                     {
                         "type": "string",
                         "example": "desc",
-                        "description": "поле для порядка сортировки (desc - по убыванию, asc - по возрастанию, по умолчанию - desc)",
+                        "description": "порядок сортировки, возможные значения: asc, desc (по умолчанию asc)",
                         "name": "order",
                         "in": "query"
                     },
@@ -357,21 +551,56 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "example": 1,
                         "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
                     },
                     "204": {
                         "description": "No Content"
                     },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            }
+                        }
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
@@ -384,31 +613,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/films/search": {
+        "/actors/deleted": {
             "get": {
-                "description": "Запрос для поиска фильмов в БД по фрагменту названия фильма и/или имени актера, по умолчанию выдает 1 самый подходящий фильм, для успешного запроса надо указать хотя бы один из фрагментов",
+                "description": "Запрос для получения содержимого корзины: удаленных актеров, начиная с удаленных последними, время удаления выводится в поле deletedAt",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Films"
+                    "Actors"
                 ],
-                "summary": "Запрос поиска фильмов в БД",
+                "summary": "Запрос получения списка удаленных актеров",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "example": "film",
-                        "description": "фрагмент названия фильма для поиска",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "Val",
-                        "description": "фрагмент имени актера для поиска",
-                        "name": "order",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "example": 1,
@@ -416,17 +631,33 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 1)",
+                        "description": "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
                     },
                     "204": {
                         "description": "No Content"
@@ -437,25 +668,1585 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Запрос для получения JWT в Cookie и теле ответа",
-                "consumes": [
-                    "application/json"
-                ],
+        "/actors/search": {
+            "get": {
+                "description": "Запрос для поиска актеров в БД по фрагменту имени, полу, диапазону дат рождения и/или фрагменту названия фильма, в котором снимался актер, для успешного запроса надо указать хотя бы один из параметров поиска. Для каждого актера также выводится список фильмов с его участием, предусмотрена пагинация. По умолчанию актеры упорядочены по имени, в нечетком режиме (fuzzy=true) имя сравнивается по триграммам с учетом опечаток, для каждого актера выводится степень сходства (similarity), актеры упорядочены по ее убыванию",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Actors"
                 ],
-                "summary": "Запрос получения токена для авторизации",
+                "summary": "Запрос поиска актеров в БД",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Val",
+                        "description": "фрагмент имени актера для поиска",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "female",
+                        "description": "пол актера, возможные значения: male, female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1970-01-01",
+                        "description": "минимальная дата рождения в формате YYYY-MM-DD",
+                        "name": "bornFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1999-12-31",
+                        "description": "максимальная дата рождения в формате YYYY-MM-DD",
+                        "name": "bornTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "film",
+                        "description": "фрагмент названия фильма, в котором снимался актер",
+                        "name": "film",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "нечеткий поиск по триграммам (по умолчанию false)",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/apikey": {
+            "post": {
+                "description": "Запрос для создания именованного API-ключа для пакетных задач, ключ передается в заголовке X-API-Key вместо JWT. Ключ с областью read может только читать фильмы, актеров и жанры, с областью write - также изменять их. Сам ключ выводится только в ответе на этот запрос, в журнале изменений действия по ключу записываются от имени api-key:\u003cid\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос создания API-ключа",
+                "parameters": [
+                    {
+                        "description": "название и область доступа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NewAPIKey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/apikey/{id}": {
+            "delete": {
+                "description": "Запрос для отзыва API-ключа, после него ключ перестает приниматься, а его название можно использовать для нового ключа",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос отзыва API-ключа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id API-ключа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/apikeys": {
+            "get": {
+                "description": "Запрос для получения списка API-ключей, включая отозванные, начиная с последних созданных, сами ключи не выводятся",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос получения списка API-ключей",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film": {
+            "post": {
+                "description": "Запрос для добавления информации о фильме в БД",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос добавления информации о фильме в БД",
+                "parameters": [
+                    {
+                        "description": "информация о фильме, актеры указываются либо списком actorIDs, либо списком cast с ролями",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Film"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}": {
+            "get": {
+                "description": "Запрос для получения информации о фильме из БД вместе со списком актеров, сыгравших в нем",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос получения фильма из БД",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия фильма для If-Match, также используется в If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия фильма для If-Match, также используется в If-None-Match"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время последнего изменения"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Запрос для замены информации о фильме, все поля, кроме actorIDs (или cast) и genres, обязательны (для частичного обновления используется PATCH)",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос обновления информации о фильме",
+                "parameters": [
+                    {
+                        "description": "информация о фильме, отсутствующие actorIDs (или cast) и genres означают, что у фильма не будет актеров и жанров",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Film"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Запрос для удаления фильма в корзину, откуда его можно восстановить или удалить окончательно",
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос удаления фильма из БД",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Запрос для частичного обновления информации о фильме, тело запроса - JSON Merge Patch (RFC 7396): отсутствующие поля не изменяются, null удаляет описание, актеров (actorIDs или cast) или жанры фильма, для остальных полей null недопустим",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос частичного обновления информации о фильме",
+                "parameters": [
+                    {
+                        "description": "изменяемые поля фильма",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Film"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/crew": {
+            "post": {
+                "description": "Запрос для указания человека из списка актеров в качестве члена съемочной группы фильма (режиссер, сценарист, продюсер, композитор, оператор, монтажер)",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос добавления человека в съемочную группу фильма",
+                "parameters": [
+                    {
+                        "description": "человек и его должность",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CrewCredit"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/crew/{personID}/{job}": {
+            "delete": {
+                "description": "Запрос для удаления должности человека в съемочной группе фильма",
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос удаления человека из съемочной группы фильма",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id человека",
+                        "name": "personID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "director",
+                        "description": "должность (director, writer, producer, composer, cinematographer, editor)",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/history": {
+            "get": {
+                "description": "Запрос для получения журнала изменений фильма, начиная с последних: кто и когда создал, изменил, удалил или восстановил запись и ее состояние до и после изменения. История сохраняется и после окончательного удаления",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос получения истории изменений фильма",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/purge": {
+            "delete": {
+                "description": "Запрос для окончательного удаления фильма из корзины вместе со всеми его связями, удалить можно только фильм из корзины",
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос окончательного удаления фильма",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/restore": {
+            "post": {
+                "description": "Запрос для восстановления фильма из корзины, связи между фильмами и актерами при удалении сохраняются, поэтому восстанавливаются вместе с ним",
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос восстановления удаленного фильма",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/film/{id}/revert/{revision}": {
+            "post": {
+                "description": "Запрос для возврата фильма вместе с актерами, съемочной группой и жанрами к состоянию после изменения из истории (revision - id записи из /film/{id}/history). Если вернуть состояние нельзя (например, актер из него окончательно удален, жанр удален или актер родился позже даты выхода фильма), возвращается 409",
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос возврата фильма к предыдущей версии",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id фильма",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id записи истории изменений",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/films": {
+            "get": {
+                "description": "Запрос для получения списка фильмов из БД, для каждого фильма также выводится список фильмов с его участием, предусмотрена фильтрация по жанру, дате выхода, рейтингу и актеру, а также пагинация, по умолчанию сортируется по убыванию рейтинга",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос получения списка фильмов из БД",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "title",
+                        "description": "поле для сортировки (release_date, rating, title, по умолчанию - rating)",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "desc",
+                        "description": "поле для порядка сортировки (desc - по убыванию, asc - по возрастанию, по умолчанию - desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "drama",
+                        "description": "название жанра, фильмы которого нужно вывести",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1990-01-01",
+                        "description": "минимальная дата выхода в формате YYYY-MM-DD",
+                        "name": "releasedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1999-12-31",
+                        "description": "максимальная дата выхода в формате YYYY-MM-DD",
+                        "name": "releasedTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 8,
+                        "description": "минимальный рейтинг, в диапазоне [0, 10]",
+                        "name": "minRating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 10,
+                        "description": "максимальный рейтинг, в диапазоне [0, 10]",
+                        "name": "maxRating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id актера, фильмы с участием которого нужно вывести",
+                        "name": "actorID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/films/deleted": {
+            "get": {
+                "description": "Запрос для получения содержимого корзины: удаленных фильмов, начиная с удаленных последними, время удаления выводится в поле deletedAt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос получения списка удаленных фильмов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/films/search": {
+            "get": {
+                "description": "Запрос для поиска фильмов в БД по тексту (по названию и описанию, с учетом морфологии русского и английского языков), фрагменту названия фильма, имени актера и/или имени режиссера, по умолчанию выдает 1 самый подходящий фильм, для успешного запроса надо указать хотя бы один из параметров поиска. При поиске по тексту фильмы упорядочены по релевантности, иначе - по убыванию рейтинга. В нечетком режиме (fuzzy=true) название и имена сравниваются по триграммам с учетом опечаток, для каждого фильма выводится степень сходства (similarity), фильмы упорядочены по ее убыванию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Films"
+                ],
+                "summary": "Запрос поиска фильмов в БД",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "космос",
+                        "description": "текст для полнотекстового поиска по названию и описанию фильма",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "film",
+                        "description": "фрагмент названия фильма для поиска",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Val",
+                        "description": "фрагмент имени актера для поиска",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Nik",
+                        "description": "фрагмент имени режиссера для поиска",
+                        "name": "director",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "drama",
+                        "description": "название жанра, среди фильмов которого производится поиск",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "нечеткий поиск по триграммам (по умолчанию false)",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 1)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "идентификатор содержимого страницы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/genre": {
+            "post": {
+                "description": "Запрос для добавления жанра в БД",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Запрос добавления жанра в БД",
+                "parameters": [
+                    {
+                        "description": "информация о жанре",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Genre"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/genre/{id}": {
+            "put": {
+                "description": "Запрос для изменения названия жанра в БД",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Запрос переименования жанра",
+                "parameters": [
+                    {
+                        "description": "информация о жанре",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Genre"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Запрос для удаления жанра из БД, у фильмов этого жанра он также будет удален",
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Запрос удаления жанра из БД",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Запрос для получения списка всех жанров из БД, отсортированного по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Запрос получения списка жанров из БД",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/lockouts": {
+            "get": {
+                "description": "Запрос для получения списка логинов и IP-адресов, вход с которыми временно запрещен из-за неудачных попыток (MAX_FAILED_LOGINS и MAX_FAILED_LOGINS_PER_IP попыток подряд блокируют вход на LOCKOUT_DURATION), начиная с последних",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос получения списка блокировок входа",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Запрос для получения короткоживущего JWT и токена обновления для /token/refresh в Cookie и теле ответа. После каждой неудачной попытки следующая с тем же логином или IP-адресом возможна лишь через время, которое удваивается с каждой попыткой (начиная с FAILED_LOGIN_BACKOFF), а после MAX_FAILED_LOGINS попыток с логином или MAX_FAILED_LOGINS_PER_IP попыток с IP-адреса вход блокируется на LOCKOUT_DURATION, до тех пор сервис отвечает 429 с заголовком Retry-After. Неизвестный логин и неверный пароль неразличимы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос получения токена для авторизации",
+                "parameters": [
+                    {
+                        "description": "аутентификационные данные",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AuthorizationData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "Запрос для выхода из сервиса: JWT из заголовка Authorization или Cookie, если он передан, перестает приниматься, токен обновления (из тела запроса или Cookie refreshToken) отзывается, Cookie очищаются. Токен обновления можно не передавать, если передан действующий JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос выхода из filmoteka",
+                "parameters": [
+                    {
+                        "description": "токен обновления, если его нет, он берется из Cookie",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/password": {
+            "post": {
+                "description": "Запрос для смены пароля по текущему паролю, новый пароль должен соответствовать той же политике, что и при регистрации, неудачные попытки ограничиваются так же, как для /login, все выданные до этого токены перестают приниматься, а в ответе, как и при /login, выдается новая пара токенов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос смены пароля",
+                "parameters": [
+                    {
+                        "description": "аутентификационные данные и новый пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PasswordChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Запрос для установки нового пароля по одноразовому токену сброса, выданному администратором, новый пароль должен соответствовать той же политике, что и при регистрации, все выданные до этого токены перестают приниматься, после сброса нужно войти через /login",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос сброса пароля",
+                "parameters": [
+                    {
+                        "description": "токен сброса пароля и новый пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PasswordReset"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Запрос для регистрации в сервисе, производится регистрация обычного пользователя (с ролью viewer; роль первого администратора нужно задать в БД в поле role таблицы auth, остальным пользователям роли назначают администраторы через /user/{login}/role), логин и пароль должны соответствовать политике (длина и допустимые символы логина, длина пароля, наличие заглавных и строчных букв и цифр, пароль не должен совпадать с логином или быть распространенным), иначе в ответе 400 перечисляются все нарушенные правила, и выдается короткоживущий JWT (можно указать в заголовке Authorization) вместе с токеном обновления для /token/refresh (оба также записываются в Cookie)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос регистрации в filmoteka",
                 "parameters": [
                     {
                         "description": "аутентификационные данные",
@@ -468,8 +2259,190 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Запрос для получения списка ролей с их разрешениями, разрешения ролей задаются в БД в таблице role_permissions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос получения списка ролей",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Запрос для получения новой пары JWT и токена обновления в обмен на токен обновления (из тела запроса или Cookie refreshToken), каждый токен обновления можно использовать только один раз, повторное использование отзывает все токены обновления пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Запрос обновления токенов",
+                "parameters": [
+                    {
+                        "description": "токен обновления, если его нет, он берется из Cookie",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}": {
+            "delete": {
+                "description": "Запрос для удаления учетной записи вместе с ее токенами обновления, записи журнала изменений, сделанные пользователем, сохраняются. Удалить самого себя нельзя",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос удаления пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/disable": {
+            "post": {
+                "description": "Запрос для отключения учетной записи: пользователь не сможет войти, его токены обновления отзываются, а JWT перестают приниматься. Отключить самого себя нельзя",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос отключения учетной записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/enable": {
+            "post": {
+                "description": "Запрос для включения отключенной учетной записи, после него пользователю нужно заново войти через /login",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос включения учетной записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -477,43 +2450,105 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/register": {
+        "/user/{login}/password/reset": {
             "post": {
-                "description": "Запрос для регистрации в сервисе, производится регистрация обычного пользователя (если нужен админ, надо задать соответствующее поле в БД в таблице auth) и выдается JWT (можно указать в заголовке Authorization) на 24 часа (также записывается в Cookie)",
-                "consumes": [
+                "description": "Запрос для выдачи одноразового токена, по которому пользователь может задать новый пароль через /password/reset, токен действует ограниченное время, выдача нового токена отменяет предыдущий",
+                "produces": [
                     "application/json"
                 ],
-                "produces": [
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос выдачи токена сброса пароля",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/role": {
+            "put": {
+                "description": "Запрос для назначения пользователю роли (viewer, editor, admin или другой роли из БД), все токены пользователя при этом отзываются, и новые разрешения он получит, заново войдя через /login. Изменить собственную роль нельзя",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Users"
                 ],
-                "summary": "Запрос регистрации в filmoteka",
+                "summary": "Запрос назначения роли пользователю",
                 "parameters": [
                     {
-                        "description": "аутентификационные данные",
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "роль",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.AuthorizationData"
+                            "$ref": "#/definitions/domain.UserRole"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "409": {
                         "description": "Conflict"
                     },
@@ -522,6 +2557,106 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/user/{login}/unlock": {
+            "post": {
+                "description": "Запрос для снятия блокировки входа с логина и сброса счетчика его неудачных попыток, блокировки IP-адресов при этом сохраняются",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос снятия блокировки входа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "login",
+                        "description": "логин пользователя",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Запрос для получения списка пользователей, отсортированного по логину, с их ролями и временем отключения учетной записи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запрос получения списка пользователей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "номер страницы, начинается с 1 (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "общее число найденных записей"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -555,6 +2690,42 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CastMember": {
+            "description": "актер, сыгравший в фильме, вместе с его ролью, если billing не указан, он определяется порядком в списке",
+            "type": "object",
+            "properties": {
+                "actorID": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "billing": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "character": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "lead"
+                }
+            }
+        },
+        "domain.CrewCredit": {
+            "description": "человек из списка актеров и его должность в съемочной группе фильма",
+            "type": "object",
+            "properties": {
+                "job": {
+                    "type": "string",
+                    "example": "director"
+                },
+                "personID": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "domain.Film": {
             "type": "object",
             "properties": {
@@ -569,10 +2740,26 @@ const docTemplate = `{
                         3
                     ]
                 },
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CastMember"
+                    }
+                },
                 "description": {
                     "type": "string",
                     "example": "some kind of film"
                 },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "rating": {
                     "type": "number",
                     "example": 8.6
@@ -586,6 +2773,81 @@ const docTemplate = `{
                     "example": "film 2"
                 }
             }
+        },
+        "domain.Genre": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "drama"
+                }
+            }
+        },
+        "domain.NewAPIKey": {
+            "description": "название и область доступа (read или write) создаваемого API-ключа",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "nightly-import"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "read"
+                }
+            }
+        },
+        "domain.PasswordChange": {
+            "description": "аутентификационные данные с новым паролем",
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "example": "login"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "example": "newpassword"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                }
+            }
+        },
+        "domain.PasswordReset": {
+            "description": "токен сброса пароля и новый пароль",
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "example": "newpassword"
+                },
+                "resetToken": {
+                    "type": "string",
+                    "example": "oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"
+                }
+            }
+        },
+        "domain.RefreshToken": {
+            "description": "токен для получения новой пары токенов через /token/refresh, действует один раз",
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"
+                }
+            }
+        },
+        "domain.UserRole": {
+            "description": "название назначаемой пользователю роли",
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                }
+            }
         }
     },
     "tags": [
@@ -597,9 +2859,17 @@ const docTemplate = `{
             "description": "Группа запросов для управления списком фильмов",
             "name": "Films"
         },
+        {
+            "description": "Группа запросов для управления списком жанров",
+            "name": "Genres"
+        },
         {
             "description": "Группа запросов для авторизации",
             "name": "Auth"
+        },
+        {
+            "description": "Группа запросов для управления пользователями и их ролями",
+            "name": "Users"
         }
     ]
 }`
@@ -8,4 +8,13 @@ var (
 	ErrActorDoesNotExist             = errors.New("one or more actors mentioned in request does not exist in database")
 	ErrAlreadyRegistered             = errors.New("user with this login is already registered")
 	ErrUserNotFound                  = errors.New("user not found")
+	ErrGenreDoesNotExist             = errors.New("one or more genres mentioned in request does not exist in database")
+	ErrGenreAlreadyExists            = errors.New("genre with this name already exists")
+	ErrPersonDoesNotExist            = errors.New("person mentioned in request does not exist in database")
+	ErrCrewCreditAlreadyExists       = errors.New("person is already credited on the film for this job")
+	ErrVersionMismatch               = errors.New("the entity was modified by someone else, its version does not match If-Match")
+	ErrRevisionNotFound              = errors.New("the requested revision does not exist in the history of the entity")
+	ErrRoleDoesNotExist              = errors.New("role mentioned in request does not exist in database")
+	ErrAPIKeyAlreadyExists           = errors.New("API key with this name already exists")
+	ErrAPIKeyNotFound                = errors.New("API key not found or already revoked")
 )
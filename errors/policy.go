@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrLoginTooShort              = errors.New("login is too short")
+	ErrLoginTooLong               = errors.New("login is too long")
+	ErrLoginHasForbiddenCharacter = errors.New("login contains forbidden characters")
+	ErrPasswordTooShort           = errors.New("password is too short")
+	ErrPasswordTooLong            = errors.New("password is too long (72 bytes is the limit)")
+	ErrPasswordHasNoUppercase     = errors.New("password should contain an uppercase letter")
+	ErrPasswordHasNoLowercase     = errors.New("password should contain a lowercase letter")
+	ErrPasswordHasNoDigit         = errors.New("password should contain a digit")
+	ErrPasswordHasNoSpecial       = errors.New("password should contain a character other than a letter or a digit")
+	ErrPasswordEqualsLogin        = errors.New("password should not be equal to the login")
+	ErrPasswordIsCommon           = errors.New("password is too common")
+)
+
+// errors.Is reports whether a particular rule is among the violated ones.
+type PolicyError struct {
+	Violations []error
+}
+
+func (e *PolicyError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		violations = append(violations, violation.Error())
+	}
+
+	return "credentials do not satisfy the policy: " + strings.Join(violations, "; ")
+}
+
+func (e *PolicyError) Unwrap() []error {
+	return e.Violations
+}
@@ -21,7 +21,42 @@ var (
 	ErrLimitParameterNotInCorrectRange = errors.New("limit parameter is not in range [1, 100]")
 	ErrNoFragmentsProvided             = errors.New("no fragments for search provided in request")
 	ErrTokenIsInvalid                  = errors.New("invalid token provided")
-	ErrWrongPassword                   = errors.New("wrong password provided")
+	ErrInvalidCredentials              = errors.New("invalid login or password provided")
+	ErrTooManyLoginAttempts            = errors.New("too many failed login attempts, try again later")
+	ErrTooManyRequests                 = errors.New("too many requests, try again later")
+	ErrAPIKeyIsInvalid                 = errors.New("invalid or revoked API key provided")
+	ErrUnknownAPIKeyScope              = errors.New("unknown API key scope used (read and write are supported)")
 	ErrNoTokenProvided                 = errors.New("no auth token provided (Cookie and Authorization Bearer supported)")
-	ErrAdminRequired                   = errors.New("admin role needed to get access to the endpoint")
+	ErrRefreshTokenIsInvalid           = errors.New("invalid, expired or already used refresh token provided")
+	ErrNoRefreshTokenProvided          = errors.New("no refresh token provided (refreshToken field and Cookie supported)")
+	ErrPermissionDenied                = errors.New("the role of the user does not have the permission needed to get access to the endpoint")
+	ErrUserIsDisabled                  = errors.New("the account is disabled by an administrator")
+	ErrNoLoginProvided                 = errors.New("login not found in request")
+	ErrOwnAccountChange                = errors.New("administrators can not change the role of, disable or delete their own account")
+	ErrNoNewPasswordProvided           = errors.New("newPassword not found in request or is empty")
+	ErrResetTokenIsInvalid             = errors.New("invalid, expired or already used password reset token provided")
+	ErrNoRoleProvided                  = errors.New("role not found in request or is empty")
+	ErrGenreNameTooLong                = errors.New("genre name is too long (50 characters is the limit)")
+	ErrActorIDsAndCastProvided         = errors.New("actorIDs and cast can not be used in one request")
+	ErrUnknownRole                     = errors.New("unknown role used (lead, supporting, cameo and voice are supported)")
+	ErrWrongBillingValue               = errors.New("billing should be a positive number")
+	ErrCharacterNameTooLong            = errors.New("character name is too long (150 characters is the limit)")
+	ErrActorMentionedTwice             = errors.New("one or more actors are mentioned in the cast more than once")
+	ErrUnknownJob                      = errors.New("unknown job used (director, writer, producer, composer, cinematographer and editor are supported)")
+	ErrNoPersonIDProvided              = errors.New("personID not found in request")
+	ErrPersonIDIsNotANumber            = errors.New("not a numeric personID provided")
+	ErrFuzzyIsNotABoolean              = errors.New("fuzzy parameter should be true or false")
+	ErrWrongDateFormat                 = errors.New("date should be in YYYY-MM-DD format")
+	ErrWrongDateRange                  = errors.New("start of the date range should not be after its end")
+	ErrNoSearchCriteriaProvided        = errors.New("no search criteria provided in request")
+	ErrRatingIsNotANumber              = errors.New("rating parameter is not a number")
+	ErrWrongRatingRange                = errors.New("minRating should not be greater than maxRating")
+	ErrActorIDIsNotANumber             = errors.New("actorID parameter is not a positive number")
+	ErrWrongCursor                     = errors.New("invalid cursor provided, it should be taken from nextCursor of the previous page with the same sorting")
+	ErrPageAndCursorProvided           = errors.New("page and after parameters can not be used in one request")
+	ErrRequiredFieldIsNull             = errors.New("only description, actorIDs, cast and genres can be removed with null")
+	ErrWrongIfMatch                    = errors.New("If-Match header should contain either * or a single entity tag from ETag")
+	ErrNoIfMatchProvided               = errors.New("If-Match header is required, it should contain the ETag of the entity or * to change it unconditionally")
+	ErrNoRevisionProvided              = errors.New("revision not found in request")
+	ErrRevisionIsNotANumber            = errors.New("not a numeric revision provided")
 )
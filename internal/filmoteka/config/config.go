@@ -1,6 +1,15 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+	ratelimiter "github.com/PoorMercymain/filmoteka/pkg/rate-limiter"
+)
 
 type Config struct {
 	PostgresUser     string `env:"POSTGRES_USER" envDefault:"filmoteka"`
@@ -12,6 +21,70 @@ type Config struct {
 	MigrationsPath   string `env:"MIGRATIONS_PATH" envDefault:"migrations"`
 	LogFilePath      string `env:"LOG_FILE_PATH" envDefault:"logfile.log"`
 	JWTKey           string `env:"JWT_KEY" envDefault:"notreallysecret"`
+	// the role and permissions in a JWT are only updated with a new token, so it is short-lived
+	AccessTokenTTL        time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL       time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h"`
+	PasswordResetTokenTTL time.Duration `env:"PASSWORD_RESET_TOKEN_TTL" envDefault:"24h"`
+	// the content of a regular expression character class
+	LoginCharacters          string              `env:"LOGIN_CHARACTERS" envDefault:"a-zA-Z0-9._-"`
+	LoginMinLength           int                 `env:"LOGIN_MIN_LENGTH" envDefault:"3"`
+	LoginMaxLength           int                 `env:"LOGIN_MAX_LENGTH" envDefault:"32"`
+	PasswordMinLength        int                 `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordRequireUppercase bool                `env:"PASSWORD_REQUIRE_UPPERCASE" envDefault:"true"`
+	PasswordRequireLowercase bool                `env:"PASSWORD_REQUIRE_LOWERCASE" envDefault:"true"`
+	PasswordRequireDigit     bool                `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"true"`
+	PasswordRequireSpecial   bool                `env:"PASSWORD_REQUIRE_SPECIAL" envDefault:"false"`
+	MaxFailedLogins          int                 `env:"MAX_FAILED_LOGINS" envDefault:"5"`
+	MaxFailedLoginsPerIP     int                 `env:"MAX_FAILED_LOGINS_PER_IP" envDefault:"20"`
+	FailedLoginBackoff       time.Duration       `env:"FAILED_LOGIN_BACKOFF" envDefault:"1s"`
+	LockoutDuration          time.Duration       `env:"LOCKOUT_DURATION" envDefault:"15m"`
+	RateLimit                int                 `env:"RATE_LIMIT" envDefault:"100"`
+	RateLimitPeriod          time.Duration       `env:"RATE_LIMIT_PERIOD" envDefault:"1m"`
+	RouteRateLimits          RouteRateLimits     `env:"ROUTE_RATE_LIMITS" envDefault:"GET /films/search:20,GET /actors/search:20,POST /register:10"`
+	SimilarityThreshold      SimilarityThreshold `env:"SIMILARITY_THRESHOLD" envDefault:"0.3"`
+}
+
+// RouteRateLimits is parsed from comma separated pattern:requests pairs.
+type RouteRateLimits map[string]int
+
+func (l *RouteRateLimits) UnmarshalText(text []byte) error {
+	limits := make(RouteRateLimits)
+	for _, pair := range strings.Split(string(text), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		i := strings.LastIndex(pair, ":")
+		if i == -1 {
+			return fmt.Errorf("config.RouteRateLimits.UnmarshalText(): %q is not a pattern:requests pair", pair)
+		}
+
+		requests, err := strconv.Atoi(strings.TrimSpace(pair[i+1:]))
+		if err != nil {
+			return fmt.Errorf("config.RouteRateLimits.UnmarshalText(): %w", err)
+		}
+
+		limits[strings.TrimSpace(pair[:i])] = requests
+	}
+
+	*l = limits
+	return nil
+}
+
+type SimilarityThreshold float64
+
+func (t *SimilarityThreshold) UnmarshalText(text []byte) error {
+	threshold, err := strconv.ParseFloat(strings.TrimSpace(string(text)), 64)
+	if err != nil {
+		return fmt.Errorf("config.SimilarityThreshold.UnmarshalText(): %w", err)
+	}
+
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("config.SimilarityThreshold.UnmarshalText(): %v is not from 0 to 1", threshold)
+	}
+
+	*t = SimilarityThreshold(threshold)
+	return nil
 }
 
 func (c *Config) DSN() string {
@@ -22,3 +95,39 @@ func (c *Config) DSN() string {
 		c.PostgresDB,
 	)
 }
+
+func (c *Config) CredentialsPolicy() (domain.CredentialsPolicy, error) {
+	loginCharacters, err := regexp.Compile("^[" + c.LoginCharacters + "]*$")
+	if err != nil {
+		return domain.CredentialsPolicy{}, fmt.Errorf("config.CredentialsPolicy(): LOGIN_CHARACTERS is not a valid character class: %w", err)
+	}
+
+	return domain.CredentialsPolicy{
+		LoginCharacters:          loginCharacters,
+		LoginMinLength:           c.LoginMinLength,
+		LoginMaxLength:           c.LoginMaxLength,
+		PasswordMinLength:        c.PasswordMinLength,
+		PasswordRequireUppercase: c.PasswordRequireUppercase,
+		PasswordRequireLowercase: c.PasswordRequireLowercase,
+		PasswordRequireDigit:     c.PasswordRequireDigit,
+		PasswordRequireSpecial:   c.PasswordRequireSpecial,
+	}, nil
+}
+
+func (c *Config) LoginThrottlePolicy() domain.LoginThrottlePolicy {
+	return domain.LoginThrottlePolicy{
+		MaxFailures:      c.MaxFailedLogins,
+		MaxFailuresPerIP: c.MaxFailedLoginsPerIP,
+		Backoff:          c.FailedLoginBackoff,
+		LockoutDuration:  c.LockoutDuration,
+	}
+}
+
+func (c *Config) RouteRateLimit(pattern string) ratelimiter.Limit {
+	requests, ok := c.RouteRateLimits[pattern]
+	if !ok {
+		requests = c.RateLimit
+	}
+
+	return ratelimiter.Limit{Requests: requests, Period: c.RateLimitPeriod}
+}
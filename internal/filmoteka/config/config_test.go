@@ -2,8 +2,12 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/caarlos0/env/v6"
 	"github.com/stretchr/testify/require"
+
+	ratelimiter "github.com/PoorMercymain/filmoteka/pkg/rate-limiter"
 )
 
 func TestConfig(t *testing.T) {
@@ -11,4 +15,43 @@ func TestConfig(t *testing.T) {
 
 	dsn := cfg.DSN()
 	require.NotEmpty(t, dsn)
+
+	cfg.LoginCharacters = "a-z"
+	policy, err := cfg.CredentialsPolicy()
+	require.NoError(t, err)
+	require.True(t, policy.LoginCharacters.MatchString("abc"))
+	require.False(t, policy.LoginCharacters.MatchString("a-c"))
+
+	cfg.LoginCharacters = "z-a"
+	_, err = cfg.CredentialsPolicy()
+	require.Error(t, err)
+}
+
+func TestRouteRateLimit(t *testing.T) {
+	cfg := Config{}
+	t.Setenv("ROUTE_RATE_LIMITS", "GET /films/search:20,POST /register:5")
+	require.NoError(t, env.Parse(&cfg))
+
+	require.Equal(t, ratelimiter.Limit{Requests: 20, Period: time.Minute}, cfg.RouteRateLimit("GET /films/search"))
+	require.Equal(t, ratelimiter.Limit{Requests: 5, Period: time.Minute}, cfg.RouteRateLimit("POST /register"))
+	require.Equal(t, ratelimiter.Limit{Requests: 100, Period: time.Minute}, cfg.RouteRateLimit("GET /films"))
+
+	var limits RouteRateLimits
+	require.Error(t, limits.UnmarshalText([]byte("GET /films")))
+	require.Error(t, limits.UnmarshalText([]byte("GET /films:abc")))
+}
+
+func TestSimilarityThreshold(t *testing.T) {
+	cfg := Config{}
+	require.NoError(t, env.Parse(&cfg))
+	require.Equal(t, SimilarityThreshold(0.3), cfg.SimilarityThreshold)
+
+	t.Setenv("SIMILARITY_THRESHOLD", "1")
+	require.NoError(t, env.Parse(&cfg))
+	require.Equal(t, SimilarityThreshold(1), cfg.SimilarityThreshold)
+
+	for _, value := range []string{"-0.1", "1.5", "abc"} {
+		t.Setenv("SIMILARITY_THRESHOLD", value)
+		require.Error(t, env.Parse(&cfg))
+	}
 }
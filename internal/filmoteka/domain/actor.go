@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"time"
+
+	jsonmergepatch "github.com/PoorMercymain/filmoteka/pkg/json-merge-patch"
+)
+
 const (
 	Male   = false
 	Female = true
@@ -11,17 +17,53 @@ type Actor struct {
 	Birthday string `json:"birthday,omitempty" example:"2001-10-25"`
 }
 
+type ActorMergePatch struct {
+	Name     jsonmergepatch.Field[string] `json:"name"`
+	Gender   jsonmergepatch.Field[string] `json:"gender"`
+	Birthday jsonmergepatch.Field[string] `json:"birthday"`
+}
+
+type ActorPatch struct {
+	Name     *string
+	Gender   *bool
+	Birthday *time.Time
+}
+
 type OutputActor struct {
-	ID       int               `json:"id"`
-	Name     string            `json:"name"`
-	Gender   string            `json:"gender"`
-	Birthday string            `json:"birthday"`
-	Films    []ActorOutputFilm `json:"films"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	Gender       string            `json:"gender"`
+	Birthday     string            `json:"birthday"`
+	Films        []ActorOutputFilm `json:"films"`
+	Similarity   *float32          `json:"similarity,omitempty"`
+	ETag         string            `json:"etag"`
+	Version      int               `json:"-"`
+	LastModified time.Time         `json:"-"`
+	DeletedAt    *time.Time        `json:"deletedAt,omitempty"`
+}
+
+type ActorSearch struct {
+	Name      string
+	Gender    *bool
+	BornFrom  time.Time
+	BornTo    time.Time
+	FilmTitle string
+	Fuzzy     bool
+	Threshold float64
 }
 
 type FilmOutputActor struct {
-	ID       int    `json:"id"`
-	Name     string `json:"name"`
-	Gender   string `json:"gender"`
-	Birthday string `json:"birthday"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Gender    string `json:"gender"`
+	Birthday  string `json:"birthday"`
+	Character string `json:"character,omitempty"`
+	Billing   int    `json:"billing"`
+	Role      string `json:"role,omitempty"`
+}
+
+type FilmOutputCrew struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Job  string `json:"job"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// NewAPIKey новый API-ключ
+// @Description название и область доступа (read или write) создаваемого API-ключа
+type NewAPIKey struct {
+	Name  string `json:"name" example:"nightly-import"`
+	Scope string `json:"scope" example:"read"`
+}
+
+// APIKey API-ключ
+// @Description API-ключ без самого ключа, revokedAt выводится только у отозванных ключей
+type APIKey struct {
+	ID         int        `json:"id" example:"1"`
+	Name       string     `json:"name" example:"nightly-import"`
+	Scope      string     `json:"scope" example:"read"`
+	CreatedBy  string     `json:"createdBy" example:"admin"`
+	CreatedAt  time.Time  `json:"createdAt" example:"2024-03-16T12:00:00Z"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" example:"2024-03-17T03:00:00Z"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" example:"2024-03-18T12:00:00Z"`
+}
+
+// The id is used since the name of a revoked key can be taken again.
+func (k APIKey) Login() string {
+	return APIKeyLoginPrefix + strconv.Itoa(k.ID)
+}
+
+// CreatedAPIKey созданный API-ключ
+// @Description созданный API-ключ, сам ключ выводится только один раз, в БД хранится лишь его хеш
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"fmk_oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditEntityFilm  = "film"
+	AuditEntityActor = "actor"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+	AuditActionRevert  = "revert"
+)
+
+// AuditRecord запись журнала изменений
+// @Description изменение фильма или актера: кто, когда и что сделал, а также состояние записи до и после изменения (null, если записи не было)
+type AuditRecord struct {
+	ID        int             `json:"id" example:"1"`
+	Login     string          `json:"login" example:"login"`
+	ChangedAt time.Time       `json:"changedAt" example:"2024-03-17T12:00:00Z"`
+	Entity    string          `json:"entity" example:"film"`
+	EntityID  int             `json:"entityID" example:"1"`
+	Action    string          `json:"action" example:"update"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+type loginKey struct{}
+
+func WithLogin(ctx context.Context, login string) context.Context {
+	return context.WithValue(ctx, loginKey{}, login)
+}
+
+func LoginFromContext(ctx context.Context) string {
+	login, _ := ctx.Value(loginKey{}).(string)
+	return login
+}
@@ -1,10 +1,124 @@
 package domain
 
+import (
+	"regexp"
+	"time"
+)
+
 type AuthorizationData struct {
 	Login    string `json:"login" example:"login"`
 	Password string `json:"password" example:"password"`
 }
 
 type Token struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshToken токен обновления
+// @Description токен для получения новой пары токенов через /token/refresh, действует один раз
+type RefreshToken struct {
+	RefreshToken string `json:"refreshToken" example:"oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"`
+}
+
+// User пользователь
+// @Description учетная запись пользователя и его роль, disabledAt выводится только у отключенных учетных записей
+type User struct {
+	ID         int        `json:"-"`
+	Login      string     `json:"login" example:"login"`
+	Role       string     `json:"role" example:"viewer"`
+	DisabledAt *time.Time `json:"disabledAt,omitempty" example:"2024-03-16T12:00:00Z"`
+}
+
+type CredentialsPolicy struct {
+	LoginCharacters          *regexp.Regexp
+	LoginMinLength           int
+	LoginMaxLength           int
+	PasswordMinLength        int
+	PasswordRequireUppercase bool
+	PasswordRequireLowercase bool
+	PasswordRequireDigit     bool
+	PasswordRequireSpecial   bool
+}
+
+// Backoff doubles with each failed attempt up to LockoutDuration, which is also the time after the last failure
+// when the counting starts over, 0 MaxFailures disables the lockout.
+type LoginThrottlePolicy struct {
+	MaxFailures      int
+	MaxFailuresPerIP int
+	Backoff          time.Duration
+	LockoutDuration  time.Duration
+}
+
+const (
+	LockoutKindLogin = "login"
+	LockoutKindIP    = "ip"
+)
+
+// maxBackoffShift keeps the doubled backoff from overflowing, the lockout duration caps it long before that anyway.
+const maxBackoffShift = 30
+
+func (p LoginThrottlePolicy) RetryAfter(failures LoginFailures, now time.Time) time.Duration {
+	if failures.Failures == 0 {
+		return 0
+	}
+
+	maxFailures := p.MaxFailures
+	if failures.Kind == LockoutKindIP {
+		maxFailures = p.MaxFailuresPerIP
+	}
+
+	delay := p.LockoutDuration
+	if maxFailures <= 0 || failures.Failures < maxFailures {
+		delay = p.Backoff << min(failures.Failures-1, maxBackoffShift)
+		if delay > p.LockoutDuration || delay < 0 {
+			delay = p.LockoutDuration
+		}
+	}
+
+	return max(failures.LastFailureAt.Add(delay).Sub(now), 0)
+}
+
+type LoginFailures struct {
+	Kind          string
+	Subject       string
+	Failures      int
+	LastFailureAt time.Time
+}
+
+// Lockout блокировка входа
+// @Description логин (kind login) или IP-адрес (kind ip), вход с которым запрещен до lockedUntil из-за неудачных попыток
+type Lockout struct {
+	Kind        string    `json:"kind" example:"login"`
+	Subject     string    `json:"subject" example:"login"`
+	Failures    int       `json:"failures" example:"5"`
+	LockedUntil time.Time `json:"lockedUntil" example:"2024-03-16T12:15:00Z"`
+}
+
+type Account struct {
+	IsDisabled bool
+	// tokens issued before SessionsRevokedAt are not accepted
+	SessionsRevokedAt *time.Time
+}
+
+// PasswordChange смена пароля
+// @Description аутентификационные данные с новым паролем
+type PasswordChange struct {
+	Login       string `json:"login" example:"login"`
+	Password    string `json:"password" example:"password"`
+	NewPassword string `json:"newPassword" example:"newpassword"`
+}
+
+// PasswordResetToken токен сброса пароля
+// @Description одноразовый токен для сброса пароля через /password/reset
+type PasswordResetToken struct {
+	ResetToken string    `json:"resetToken" example:"oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"`
+	ExpiresAt  time.Time `json:"expiresAt" example:"2024-03-17T12:00:00Z"`
+}
+
+// PasswordReset сброс пароля
+// @Description токен сброса пароля и новый пароль
+type PasswordReset struct {
+	ResetToken  string `json:"resetToken" example:"oB6xA5mQ0e3Xkq3oQ2c0mFq7l5i5sL0bGk8w2n3pZ1E"`
+	NewPassword string `json:"newPassword" example:"newpassword"`
 }
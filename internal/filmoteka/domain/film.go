@@ -1,20 +1,106 @@
 package domain
 
+import (
+	"time"
+
+	jsonmergepatch "github.com/PoorMercymain/filmoteka/pkg/json-merge-patch"
+)
+
+const (
+	RoleLead       = "lead"
+	RoleSupporting = "supporting"
+	RoleCameo      = "cameo"
+	RoleVoice      = "voice"
+)
+
+const (
+	JobDirector        = "director"
+	JobWriter          = "writer"
+	JobProducer        = "producer"
+	JobComposer        = "composer"
+	JobCinematographer = "cinematographer"
+	JobEditor          = "editor"
+)
+
 type Film struct {
-	Title       string   `json:"title,omitempty" example:"film 2"`
-	Description string   `json:"description,omitempty" example:"some kind of film"`
-	ReleaseDate string   `json:"releaseDate,omitempty" example:"2007-09-20"`
-	Rating      *float32 `json:"rating,omitempty" example:"8.6"`
-	Actors      []int    `json:"actorIDs" example:"1,2,3"`
+	Title       string       `json:"title,omitempty" example:"film 2"`
+	Description string       `json:"description,omitempty" example:"some kind of film"`
+	ReleaseDate string       `json:"releaseDate,omitempty" example:"2007-09-20"`
+	Rating      *float32     `json:"rating,omitempty" example:"8.6"`
+	Actors      []int        `json:"actorIDs" example:"1,2,3"`
+	Cast        []CastMember `json:"cast"`
+	Genres      []int        `json:"genres" example:"1,2"`
+}
+
+type FilmMergePatch struct {
+	Title       jsonmergepatch.Field[string]       `json:"title"`
+	Description jsonmergepatch.Field[string]       `json:"description"`
+	ReleaseDate jsonmergepatch.Field[string]       `json:"releaseDate"`
+	Rating      jsonmergepatch.Field[float32]      `json:"rating"`
+	Actors      jsonmergepatch.Field[[]int]        `json:"actorIDs"`
+	Cast        jsonmergepatch.Field[[]CastMember] `json:"cast"`
+	Genres      jsonmergepatch.Field[[]int]        `json:"genres"`
+}
+
+// FilmPatch holds changes of a film, nil fields are left as they are, empty Cast and Genres clear them.
+type FilmPatch struct {
+	Title       *string
+	Description *string
+	ReleaseDate *time.Time
+	Rating      *float32
+	Cast        []CastMember
+	Genres      []int
+}
+
+// CastMember роль актера в фильме
+// @Description актер, сыгравший в фильме, вместе с его ролью, если billing не указан, он определяется порядком в списке
+type CastMember struct {
+	ActorID   int    `json:"actorID" example:"1"`
+	Character string `json:"character,omitempty" example:"Ivan"`
+	Billing   int    `json:"billing,omitempty" example:"1"`
+	Role      string `json:"role,omitempty" example:"lead"`
+}
+
+// CrewCredit участие человека в съемочной группе фильма
+// @Description человек из списка актеров и его должность в съемочной группе фильма
+type CrewCredit struct {
+	PersonID int    `json:"personID" example:"1"`
+	Job      string `json:"job" example:"director"`
+}
+
+type FilmFilter struct {
+	Genre        string
+	ReleasedFrom time.Time
+	ReleasedTo   time.Time
+	MinRating    *float32
+	MaxRating    *float32
+	ActorID      int
+}
+
+type FilmSearch struct {
+	Query        string
+	Title        string
+	ActorName    string
+	DirectorName string
+	Genre        string
+	Fuzzy        bool
+	Threshold    float64
 }
 
 type OutputFilm struct {
-	ID          int               `json:"id"`
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	ReleaseDate string            `json:"releaseDate"`
-	Rating      float32           `json:"rating"`
-	Actors      []FilmOutputActor `json:"actors"`
+	ID           int               `json:"id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	ReleaseDate  string            `json:"releaseDate"`
+	Rating       float32           `json:"rating"`
+	Actors       []FilmOutputActor `json:"actors"`
+	Crew         []FilmOutputCrew  `json:"crew"`
+	Genres       []OutputGenre     `json:"genres"`
+	Similarity   *float32          `json:"similarity,omitempty"`
+	ETag         string            `json:"etag"`
+	Version      int               `json:"-"`
+	LastModified time.Time         `json:"-"`
+	DeletedAt    *time.Time        `json:"deletedAt,omitempty"`
 }
 
 type ActorOutputFilm struct {
@@ -23,4 +109,7 @@ type ActorOutputFilm struct {
 	Description string  `json:"description"`
 	ReleaseDate string  `json:"releaseDate"`
 	Rating      float32 `json:"rating"`
+	Character   string  `json:"character,omitempty"`
+	Billing     int     `json:"billing"`
+	Role        string  `json:"role,omitempty"`
 }
@@ -6,46 +6,137 @@ import (
 )
 
 type FilmService interface {
-	CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, actors []int) (int, error)
-	UpdateFilm(ctx context.Context, id int, title string, description string, releaseDate time.Time, rating *float32, actors []int) error
-	DeleteFilm(ctx context.Context, id int) error
-	ReadFilms(ctx context.Context, field string, order string, page int, limit int) ([]OutputFilm, error)
-	FindFilms(ctx context.Context, filmTitleFragment string, actorNameFragment string, page int, limit int) ([]OutputFilm, error)
+	CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, cast []CastMember, genres []int) (int, error)
+	UpdateFilm(ctx context.Context, id int, version int, title string, description string, releaseDate time.Time, rating float32, cast []CastMember, genres []int) error
+	PatchFilm(ctx context.Context, id int, version int, patch FilmPatch) error
+	RevertFilm(ctx context.Context, id int, version int, revision int) error
+	DeleteFilm(ctx context.Context, id int, version int) error
+	RestoreFilm(ctx context.Context, id int) error
+	PurgeFilm(ctx context.Context, id int) error
+	ReadDeletedFilms(ctx context.Context, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	ReadFilmHistory(ctx context.Context, id int, pagination Pagination) ([]AuditRecord, PageInfo, error)
+	ReadFilm(ctx context.Context, id int) (OutputFilm, error)
+	ReadFilms(ctx context.Context, field string, order string, filter FilmFilter, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	FindFilms(ctx context.Context, search FilmSearch, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	AddCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error
+	DeleteCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error
 }
 
 //go:generate mockgen -destination=mocks/film_repo_mock.gen.go -package=mocks . FilmRepository
 type FilmRepository interface {
-	CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, actors []int) (int, error)
-	UpdateFilm(ctx context.Context, id int, title string, description string, releaseDate time.Time, rating *float32, actors []int) error
-	DeleteFilm(ctx context.Context, id int) error
-	ReadFilms(ctx context.Context, field string, order string, page int, limit int) ([]OutputFilm, error)
-	FindFilms(ctx context.Context, filmTitleFragment string, actorNameFragment string, page int, limit int) ([]OutputFilm, error)
+	CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, cast []CastMember, genres []int) (int, error)
+	UpdateFilm(ctx context.Context, id int, version int, title string, description string, releaseDate time.Time, rating float32, cast []CastMember, genres []int) error
+	PatchFilm(ctx context.Context, id int, version int, patch FilmPatch) error
+	RevertFilm(ctx context.Context, id int, version int, revision int) error
+	DeleteFilm(ctx context.Context, id int, version int) error
+	RestoreFilm(ctx context.Context, id int) error
+	PurgeFilm(ctx context.Context, id int) error
+	ReadDeletedFilms(ctx context.Context, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	ReadFilmHistory(ctx context.Context, id int, pagination Pagination) ([]AuditRecord, PageInfo, error)
+	ReadFilm(ctx context.Context, id int) (OutputFilm, error)
+	ReadFilms(ctx context.Context, field string, order string, filter FilmFilter, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	FindFilms(ctx context.Context, search FilmSearch, pagination Pagination) ([]OutputFilm, PageInfo, error)
+	AddCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error
+	DeleteCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error
 }
 
 type ActorService interface {
 	CreateActor(ctx context.Context, name string, gender bool, birthday time.Time) (int, error)
-	UpdateActor(ctx context.Context, id int, name string, gender *bool, birthday time.Time) error
-	DeleteActor(ctx context.Context, id int) error
-	ReadActors(ctx context.Context, page int, limit int) ([]OutputActor, error)
+	UpdateActor(ctx context.Context, id int, version int, name string, gender bool, birthday time.Time) error
+	PatchActor(ctx context.Context, id int, version int, patch ActorPatch) error
+	RevertActor(ctx context.Context, id int, version int, revision int) error
+	DeleteActor(ctx context.Context, id int, version int) error
+	RestoreActor(ctx context.Context, id int) error
+	PurgeActor(ctx context.Context, id int) error
+	ReadDeletedActors(ctx context.Context, pagination Pagination) ([]OutputActor, PageInfo, error)
+	ReadActorHistory(ctx context.Context, id int, pagination Pagination) ([]AuditRecord, PageInfo, error)
+	ReadActor(ctx context.Context, id int) (OutputActor, error)
+	ReadActors(ctx context.Context, field string, order string, pagination Pagination) ([]OutputActor, PageInfo, error)
+	FindActors(ctx context.Context, search ActorSearch, pagination Pagination) ([]OutputActor, PageInfo, error)
 }
 
 //go:generate mockgen -destination=mocks/actor_repo_mock.gen.go -package=mocks . ActorRepository
 type ActorRepository interface {
 	CreateActor(ctx context.Context, name string, gender bool, birthday time.Time) (int, error)
-	UpdateActor(ctx context.Context, id int, name string, gender *bool, birthday time.Time) error
-	DeleteActor(ctx context.Context, id int) error
-	ReadActors(ctx context.Context, page int, limit int) ([]OutputActor, error)
+	UpdateActor(ctx context.Context, id int, version int, name string, gender bool, birthday time.Time) error
+	PatchActor(ctx context.Context, id int, version int, patch ActorPatch) error
+	RevertActor(ctx context.Context, id int, version int, revision int) error
+	DeleteActor(ctx context.Context, id int, version int) error
+	RestoreActor(ctx context.Context, id int) error
+	PurgeActor(ctx context.Context, id int) error
+	ReadDeletedActors(ctx context.Context, pagination Pagination) ([]OutputActor, PageInfo, error)
+	ReadActorHistory(ctx context.Context, id int, pagination Pagination) ([]AuditRecord, PageInfo, error)
+	ReadActor(ctx context.Context, id int) (OutputActor, error)
+	ReadActors(ctx context.Context, field string, order string, pagination Pagination) ([]OutputActor, PageInfo, error)
+	FindActors(ctx context.Context, search ActorSearch, pagination Pagination) ([]OutputActor, PageInfo, error)
+}
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, name string) (int, error)
+	UpdateGenre(ctx context.Context, id int, name string) error
+	DeleteGenre(ctx context.Context, id int) error
+	ReadGenres(ctx context.Context) ([]OutputGenre, error)
+}
+
+//go:generate mockgen -destination=mocks/genre_repo_mock.gen.go -package=mocks . GenreRepository
+type GenreRepository interface {
+	CreateGenre(ctx context.Context, name string) (int, error)
+	UpdateGenre(ctx context.Context, id int, name string) error
+	DeleteGenre(ctx context.Context, id int) error
+	ReadGenres(ctx context.Context) ([]OutputGenre, error)
 }
 
 type AuthorizationService interface {
 	Register(ctx context.Context, login string, password string) error
-	CheckAuth(ctx context.Context, login string, password string) error
-	IsAdmin(ctx context.Context, login string) (bool, error)
+	CheckAuth(ctx context.Context, login string, password string, ip string) error
+	GetRole(ctx context.Context, login string) (Role, error)
+	CreateRefreshToken(ctx context.Context, login string, expiresAt time.Time) (string, error)
+	RotateRefreshToken(ctx context.Context, refreshToken string, expiresAt time.Time) (string, string, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	CheckSession(ctx context.Context, login string, issuedAt time.Time) error
+	ChangePassword(ctx context.Context, login string, password string, newPassword string, ip string) error
+	CreatePasswordResetToken(ctx context.Context, login string, expiresAt time.Time) (string, error)
+	ResetPassword(ctx context.Context, resetToken string, newPassword string) error
+	ReadUsers(ctx context.Context, pagination Pagination) ([]User, PageInfo, error)
+	ReadRoles(ctx context.Context) ([]Role, error)
+	SetRole(ctx context.Context, login string, role string) error
+	SetDisabled(ctx context.Context, login string, isDisabled bool) error
+	DeleteUser(ctx context.Context, login string) error
+	ReadLockouts(ctx context.Context) ([]Lockout, error)
+	Unlock(ctx context.Context, login string) error
+	CreateAPIKey(ctx context.Context, name string, scope string) (CreatedAPIKey, error)
+	ReadAPIKeys(ctx context.Context) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	CheckAPIKey(ctx context.Context, key string) (APIKey, error)
 }
 
 //go:generate mockgen -destination=mocks/authorization_repo_mock.gen.go -package=mocks . AuthorizationRepository
 type AuthorizationRepository interface {
 	Register(ctx context.Context, login string, passwordHash string) error
 	GetPasswordHash(ctx context.Context, login string) (string, error)
-	IsAdmin(ctx context.Context, login string) (bool, error)
+	GetRole(ctx context.Context, login string) (Role, error)
+	CreateRefreshToken(ctx context.Context, login string, tokenHash string, expiresAt time.Time) error
+	RotateRefreshToken(ctx context.Context, tokenHash string, newTokenHash string, expiresAt time.Time) (string, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string) error
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	GetAccount(ctx context.Context, login string) (Account, error)
+	SetPassword(ctx context.Context, login string, passwordHash string, changedAt time.Time) error
+	CreatePasswordResetToken(ctx context.Context, login string, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash string, passwordHash string, changedAt time.Time) error
+	ReadUsers(ctx context.Context, pagination Pagination) ([]User, PageInfo, error)
+	ReadRoles(ctx context.Context) ([]Role, error)
+	SetRole(ctx context.Context, login string, role string) error
+	SetDisabled(ctx context.Context, login string, isDisabled bool) error
+	DeleteUser(ctx context.Context, login string) error
+	ReserveLoginAttempt(ctx context.Context, login string, ip string, policy LoginThrottlePolicy, attemptAt time.Time) (time.Duration, error)
+	ReleaseLoginAttempt(ctx context.Context, login string, ip string) error
+	ResetLoginFailures(ctx context.Context, login string) error
+	ReadLockouts(ctx context.Context, lockedSince time.Time, maxFailures int, maxFailuresPerIP int) ([]LoginFailures, error)
+	CreateAPIKey(ctx context.Context, name string, scope string, keyHash string) (APIKey, error)
+	ReadAPIKeys(ctx context.Context) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	CheckAPIKey(ctx context.Context, keyHash string) (APIKey, error)
 }
@@ -0,0 +1,10 @@
+package domain
+
+type Genre struct {
+	Name string `json:"name" example:"drama"`
+}
+
+type OutputGenre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -52,44 +52,164 @@ func (mr *MockActorRepositoryMockRecorder) CreateActor(arg0, arg1, arg2, arg3 in
 }
 
 // DeleteActor mocks base method.
-func (m *MockActorRepository) DeleteActor(arg0 context.Context, arg1 int) error {
+func (m *MockActorRepository) DeleteActor(arg0 context.Context, arg1, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteActor", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockActorRepositoryMockRecorder) DeleteActor(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockActorRepositoryMockRecorder) DeleteActor(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorRepository)(nil).DeleteActor), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorRepository)(nil).DeleteActor), arg0, arg1, arg2)
 }
 
-// ReadActors mocks base method.
-func (m *MockActorRepository) ReadActors(arg0 context.Context, arg1, arg2 int) ([]domain.OutputActor, error) {
+// FindActors mocks base method.
+func (m *MockActorRepository) FindActors(arg0 context.Context, arg1 domain.ActorSearch, arg2 domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadActors", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "FindActors", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]domain.OutputActor)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindActors indicates an expected call of FindActors.
+func (mr *MockActorRepositoryMockRecorder) FindActors(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActors", reflect.TypeOf((*MockActorRepository)(nil).FindActors), arg0, arg1, arg2)
+}
+
+// PatchActor mocks base method.
+func (m *MockActorRepository) PatchActor(arg0 context.Context, arg1, arg2 int, arg3 domain.ActorPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchActor", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchActor indicates an expected call of PatchActor.
+func (mr *MockActorRepositoryMockRecorder) PatchActor(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchActor", reflect.TypeOf((*MockActorRepository)(nil).PatchActor), arg0, arg1, arg2, arg3)
+}
+
+// PurgeActor mocks base method.
+func (m *MockActorRepository) PurgeActor(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeActor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeActor indicates an expected call of PurgeActor.
+func (mr *MockActorRepositoryMockRecorder) PurgeActor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeActor", reflect.TypeOf((*MockActorRepository)(nil).PurgeActor), arg0, arg1)
+}
+
+// ReadActor mocks base method.
+func (m *MockActorRepository) ReadActor(arg0 context.Context, arg1 int) (domain.OutputActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActor", arg0, arg1)
+	ret0, _ := ret[0].(domain.OutputActor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ReadActor indicates an expected call of ReadActor.
+func (mr *MockActorRepositoryMockRecorder) ReadActor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActor", reflect.TypeOf((*MockActorRepository)(nil).ReadActor), arg0, arg1)
+}
+
+// ReadActorHistory mocks base method.
+func (m *MockActorRepository) ReadActorHistory(arg0 context.Context, arg1 int, arg2 domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActorHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.AuditRecord)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadActorHistory indicates an expected call of ReadActorHistory.
+func (mr *MockActorRepositoryMockRecorder) ReadActorHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActorHistory", reflect.TypeOf((*MockActorRepository)(nil).ReadActorHistory), arg0, arg1, arg2)
+}
+
+// ReadActors mocks base method.
+func (m *MockActorRepository) ReadActors(arg0 context.Context, arg1, arg2 string, arg3 domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActors", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.OutputActor)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ReadActors indicates an expected call of ReadActors.
-func (mr *MockActorRepositoryMockRecorder) ReadActors(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockActorRepositoryMockRecorder) ReadActors(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActors", reflect.TypeOf((*MockActorRepository)(nil).ReadActors), arg0, arg1, arg2, arg3)
+}
+
+// ReadDeletedActors mocks base method.
+func (m *MockActorRepository) ReadDeletedActors(arg0 context.Context, arg1 domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDeletedActors", arg0, arg1)
+	ret0, _ := ret[0].([]domain.OutputActor)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadDeletedActors indicates an expected call of ReadDeletedActors.
+func (mr *MockActorRepositoryMockRecorder) ReadDeletedActors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDeletedActors", reflect.TypeOf((*MockActorRepository)(nil).ReadDeletedActors), arg0, arg1)
+}
+
+// RestoreActor mocks base method.
+func (m *MockActorRepository) RestoreActor(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreActor indicates an expected call of RestoreActor.
+func (mr *MockActorRepositoryMockRecorder) RestoreActor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActor", reflect.TypeOf((*MockActorRepository)(nil).RestoreActor), arg0, arg1)
+}
+
+// RevertActor mocks base method.
+func (m *MockActorRepository) RevertActor(arg0 context.Context, arg1, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertActor", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertActor indicates an expected call of RevertActor.
+func (mr *MockActorRepositoryMockRecorder) RevertActor(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActors", reflect.TypeOf((*MockActorRepository)(nil).ReadActors), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertActor", reflect.TypeOf((*MockActorRepository)(nil).RevertActor), arg0, arg1, arg2, arg3)
 }
 
 // UpdateActor mocks base method.
-func (m *MockActorRepository) UpdateActor(arg0 context.Context, arg1 int, arg2 string, arg3 *bool, arg4 time.Time) error {
+func (m *MockActorRepository) UpdateActor(arg0 context.Context, arg1, arg2 int, arg3 string, arg4 bool, arg5 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActor", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "UpdateActor", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActor indicates an expected call of UpdateActor.
-func (mr *MockActorRepositoryMockRecorder) UpdateActor(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockActorRepositoryMockRecorder) UpdateActor(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorRepository)(nil).UpdateActor), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorRepository)(nil).UpdateActor), arg0, arg1, arg2, arg3, arg4, arg5)
 }
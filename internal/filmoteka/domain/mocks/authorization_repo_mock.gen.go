@@ -7,7 +7,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	domain "github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,6 +36,93 @@ func (m *MockAuthorizationRepository) EXPECT() *MockAuthorizationRepositoryMockR
 	return m.recorder
 }
 
+// CheckAPIKey mocks base method.
+func (m *MockAuthorizationRepository) CheckAPIKey(arg0 context.Context, arg1 string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAPIKey indicates an expected call of CheckAPIKey.
+func (mr *MockAuthorizationRepositoryMockRecorder) CheckAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAPIKey", reflect.TypeOf((*MockAuthorizationRepository)(nil).CheckAPIKey), arg0, arg1)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAuthorizationRepository) CreateAPIKey(arg0 context.Context, arg1, arg2, arg3 string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAuthorizationRepositoryMockRecorder) CreateAPIKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAuthorizationRepository)(nil).CreateAPIKey), arg0, arg1, arg2, arg3)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockAuthorizationRepository) CreatePasswordResetToken(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockAuthorizationRepositoryMockRecorder) CreatePasswordResetToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockAuthorizationRepository)(nil).CreatePasswordResetToken), arg0, arg1, arg2, arg3)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockAuthorizationRepository) CreateRefreshToken(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockAuthorizationRepositoryMockRecorder) CreateRefreshToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthorizationRepository)(nil).CreateRefreshToken), arg0, arg1, arg2, arg3)
+}
+
+// DeleteUser mocks base method.
+func (m *MockAuthorizationRepository) DeleteUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAuthorizationRepositoryMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthorizationRepository)(nil).DeleteUser), arg0, arg1)
+}
+
+// GetAccount mocks base method.
+func (m *MockAuthorizationRepository) GetAccount(arg0 context.Context, arg1 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAuthorizationRepositoryMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAuthorizationRepository)(nil).GetAccount), arg0, arg1)
+}
+
 // GetPasswordHash mocks base method.
 func (m *MockAuthorizationRepository) GetPasswordHash(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,19 +138,95 @@ func (mr *MockAuthorizationRepositoryMockRecorder) GetPasswordHash(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHash", reflect.TypeOf((*MockAuthorizationRepository)(nil).GetPasswordHash), arg0, arg1)
 }
 
-// IsAdmin mocks base method.
-func (m *MockAuthorizationRepository) IsAdmin(arg0 context.Context, arg1 string) (bool, error) {
+// GetRole mocks base method.
+func (m *MockAuthorizationRepository) GetRole(arg0 context.Context, arg1 string) (domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
+	ret0, _ := ret[0].(domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockAuthorizationRepositoryMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockAuthorizationRepository)(nil).GetRole), arg0, arg1)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockAuthorizationRepository) IsTokenRevoked(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsAdmin", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsAdmin indicates an expected call of IsAdmin.
-func (mr *MockAuthorizationRepositoryMockRecorder) IsAdmin(arg0, arg1 interface{}) *gomock.Call {
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockAuthorizationRepositoryMockRecorder) IsTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockAuthorizationRepository)(nil).IsTokenRevoked), arg0, arg1)
+}
+
+// ReadAPIKeys mocks base method.
+func (m *MockAuthorizationRepository) ReadAPIKeys(arg0 context.Context) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAPIKeys", arg0)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAPIKeys indicates an expected call of ReadAPIKeys.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReadAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAPIKeys", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReadAPIKeys), arg0)
+}
+
+// ReadLockouts mocks base method.
+func (m *MockAuthorizationRepository) ReadLockouts(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]domain.LoginFailures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLockouts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.LoginFailures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLockouts indicates an expected call of ReadLockouts.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReadLockouts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLockouts", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReadLockouts), arg0, arg1, arg2, arg3)
+}
+
+// ReadRoles mocks base method.
+func (m *MockAuthorizationRepository) ReadRoles(arg0 context.Context) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRoles", arg0)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRoles indicates an expected call of ReadRoles.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReadRoles(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockAuthorizationRepository)(nil).IsAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRoles", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReadRoles), arg0)
+}
+
+// ReadUsers mocks base method.
+func (m *MockAuthorizationRepository) ReadUsers(arg0 context.Context, arg1 domain.Pagination) ([]domain.User, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsers", arg0, arg1)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadUsers indicates an expected call of ReadUsers.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReadUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReadUsers), arg0, arg1)
 }
 
 // Register mocks base method.
@@ -77,3 +242,159 @@ func (mr *MockAuthorizationRepositoryMockRecorder) Register(arg0, arg1, arg2 int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthorizationRepository)(nil).Register), arg0, arg1, arg2)
 }
+
+// ReleaseLoginAttempt mocks base method.
+func (m *MockAuthorizationRepository) ReleaseLoginAttempt(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLoginAttempt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLoginAttempt indicates an expected call of ReleaseLoginAttempt.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReleaseLoginAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLoginAttempt", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReleaseLoginAttempt), arg0, arg1, arg2)
+}
+
+// ReserveLoginAttempt mocks base method.
+func (m *MockAuthorizationRepository) ReserveLoginAttempt(arg0 context.Context, arg1, arg2 string, arg3 domain.LoginThrottlePolicy, arg4 time.Time) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveLoginAttempt", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveLoginAttempt indicates an expected call of ReserveLoginAttempt.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReserveLoginAttempt(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveLoginAttempt", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReserveLoginAttempt), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockAuthorizationRepository) ResetLoginFailures(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockAuthorizationRepositoryMockRecorder) ResetLoginFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockAuthorizationRepository)(nil).ResetLoginFailures), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthorizationRepository) ResetPassword(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthorizationRepositoryMockRecorder) ResetPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthorizationRepository)(nil).ResetPassword), arg0, arg1, arg2, arg3)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAuthorizationRepository) RevokeAPIKey(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAuthorizationRepositoryMockRecorder) RevokeAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAuthorizationRepository)(nil).RevokeAPIKey), arg0, arg1)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockAuthorizationRepository) RevokeRefreshToken(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockAuthorizationRepositoryMockRecorder) RevokeRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockAuthorizationRepository)(nil).RevokeRefreshToken), arg0, arg1)
+}
+
+// RevokeToken mocks base method.
+func (m *MockAuthorizationRepository) RevokeToken(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAuthorizationRepositoryMockRecorder) RevokeToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAuthorizationRepository)(nil).RevokeToken), arg0, arg1, arg2)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockAuthorizationRepository) RotateRefreshToken(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockAuthorizationRepositoryMockRecorder) RotateRefreshToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockAuthorizationRepository)(nil).RotateRefreshToken), arg0, arg1, arg2, arg3)
+}
+
+// SetDisabled mocks base method.
+func (m *MockAuthorizationRepository) SetDisabled(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockAuthorizationRepositoryMockRecorder) SetDisabled(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockAuthorizationRepository)(nil).SetDisabled), arg0, arg1, arg2)
+}
+
+// SetPassword mocks base method.
+func (m *MockAuthorizationRepository) SetPassword(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockAuthorizationRepositoryMockRecorder) SetPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockAuthorizationRepository)(nil).SetPassword), arg0, arg1, arg2, arg3)
+}
+
+// SetRole mocks base method.
+func (m *MockAuthorizationRepository) SetRole(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAuthorizationRepositoryMockRecorder) SetRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAuthorizationRepository)(nil).SetRole), arg0, arg1, arg2)
+}
@@ -36,57 +36,162 @@ func (m *MockFilmRepository) EXPECT() *MockFilmRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddCrewCredit mocks base method.
+func (m *MockFilmRepository) AddCrewCredit(arg0 context.Context, arg1, arg2, arg3 int, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCrewCredit", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCrewCredit indicates an expected call of AddCrewCredit.
+func (mr *MockFilmRepositoryMockRecorder) AddCrewCredit(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCrewCredit", reflect.TypeOf((*MockFilmRepository)(nil).AddCrewCredit), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateFilm mocks base method.
-func (m *MockFilmRepository) CreateFilm(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 float32, arg5 []int) (int, error) {
+func (m *MockFilmRepository) CreateFilm(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 float32, arg5 []domain.CastMember, arg6 []int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFilm", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret := m.ctrl.Call(m, "CreateFilm", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateFilm indicates an expected call of CreateFilm.
-func (mr *MockFilmRepositoryMockRecorder) CreateFilm(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) CreateFilm(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockFilmRepository)(nil).CreateFilm), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// DeleteCrewCredit mocks base method.
+func (m *MockFilmRepository) DeleteCrewCredit(arg0 context.Context, arg1, arg2, arg3 int, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCrewCredit", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCrewCredit indicates an expected call of DeleteCrewCredit.
+func (mr *MockFilmRepositoryMockRecorder) DeleteCrewCredit(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockFilmRepository)(nil).CreateFilm), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCrewCredit", reflect.TypeOf((*MockFilmRepository)(nil).DeleteCrewCredit), arg0, arg1, arg2, arg3, arg4)
 }
 
 // DeleteFilm mocks base method.
-func (m *MockFilmRepository) DeleteFilm(arg0 context.Context, arg1 int) error {
+func (m *MockFilmRepository) DeleteFilm(arg0 context.Context, arg1, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFilm", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteFilm", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFilm indicates an expected call of DeleteFilm.
-func (mr *MockFilmRepositoryMockRecorder) DeleteFilm(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) DeleteFilm(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockFilmRepository)(nil).DeleteFilm), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockFilmRepository)(nil).DeleteFilm), arg0, arg1, arg2)
 }
 
 // FindFilms mocks base method.
-func (m *MockFilmRepository) FindFilms(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]domain.OutputFilm, error) {
+func (m *MockFilmRepository) FindFilms(arg0 context.Context, arg1 domain.FilmSearch, arg2 domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindFilms", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "FindFilms", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]domain.OutputFilm)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindFilms indicates an expected call of FindFilms.
+func (mr *MockFilmRepositoryMockRecorder) FindFilms(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilms", reflect.TypeOf((*MockFilmRepository)(nil).FindFilms), arg0, arg1, arg2)
+}
+
+// PatchFilm mocks base method.
+func (m *MockFilmRepository) PatchFilm(arg0 context.Context, arg1, arg2 int, arg3 domain.FilmPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchFilm", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchFilm indicates an expected call of PatchFilm.
+func (mr *MockFilmRepositoryMockRecorder) PatchFilm(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFilm", reflect.TypeOf((*MockFilmRepository)(nil).PatchFilm), arg0, arg1, arg2, arg3)
+}
+
+// PurgeFilm mocks base method.
+func (m *MockFilmRepository) PurgeFilm(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFilm", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeFilm indicates an expected call of PurgeFilm.
+func (mr *MockFilmRepositoryMockRecorder) PurgeFilm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFilm", reflect.TypeOf((*MockFilmRepository)(nil).PurgeFilm), arg0, arg1)
+}
+
+// ReadDeletedFilms mocks base method.
+func (m *MockFilmRepository) ReadDeletedFilms(arg0 context.Context, arg1 domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadDeletedFilms", arg0, arg1)
+	ret0, _ := ret[0].([]domain.OutputFilm)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadDeletedFilms indicates an expected call of ReadDeletedFilms.
+func (mr *MockFilmRepositoryMockRecorder) ReadDeletedFilms(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadDeletedFilms", reflect.TypeOf((*MockFilmRepository)(nil).ReadDeletedFilms), arg0, arg1)
+}
+
+// ReadFilm mocks base method.
+func (m *MockFilmRepository) ReadFilm(arg0 context.Context, arg1 int) (domain.OutputFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFilm", arg0, arg1)
+	ret0, _ := ret[0].(domain.OutputFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindFilms indicates an expected call of FindFilms.
-func (mr *MockFilmRepositoryMockRecorder) FindFilms(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// ReadFilm indicates an expected call of ReadFilm.
+func (mr *MockFilmRepositoryMockRecorder) ReadFilm(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilms", reflect.TypeOf((*MockFilmRepository)(nil).FindFilms), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFilm", reflect.TypeOf((*MockFilmRepository)(nil).ReadFilm), arg0, arg1)
+}
+
+// ReadFilmHistory mocks base method.
+func (m *MockFilmRepository) ReadFilmHistory(arg0 context.Context, arg1 int, arg2 domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFilmHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.AuditRecord)
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadFilmHistory indicates an expected call of ReadFilmHistory.
+func (mr *MockFilmRepositoryMockRecorder) ReadFilmHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFilmHistory", reflect.TypeOf((*MockFilmRepository)(nil).ReadFilmHistory), arg0, arg1, arg2)
 }
 
 // ReadFilms mocks base method.
-func (m *MockFilmRepository) ReadFilms(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]domain.OutputFilm, error) {
+func (m *MockFilmRepository) ReadFilms(arg0 context.Context, arg1, arg2 string, arg3 domain.FilmFilter, arg4 domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReadFilms", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]domain.OutputFilm)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ReadFilms indicates an expected call of ReadFilms.
@@ -95,16 +200,44 @@ func (mr *MockFilmRepositoryMockRecorder) ReadFilms(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFilms", reflect.TypeOf((*MockFilmRepository)(nil).ReadFilms), arg0, arg1, arg2, arg3, arg4)
 }
 
+// RestoreFilm mocks base method.
+func (m *MockFilmRepository) RestoreFilm(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFilm", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFilm indicates an expected call of RestoreFilm.
+func (mr *MockFilmRepositoryMockRecorder) RestoreFilm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFilm", reflect.TypeOf((*MockFilmRepository)(nil).RestoreFilm), arg0, arg1)
+}
+
+// RevertFilm mocks base method.
+func (m *MockFilmRepository) RevertFilm(arg0 context.Context, arg1, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertFilm", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertFilm indicates an expected call of RevertFilm.
+func (mr *MockFilmRepositoryMockRecorder) RevertFilm(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertFilm", reflect.TypeOf((*MockFilmRepository)(nil).RevertFilm), arg0, arg1, arg2, arg3)
+}
+
 // UpdateFilm mocks base method.
-func (m *MockFilmRepository) UpdateFilm(arg0 context.Context, arg1 int, arg2, arg3 string, arg4 time.Time, arg5 *float32, arg6 []int) error {
+func (m *MockFilmRepository) UpdateFilm(arg0 context.Context, arg1, arg2 int, arg3, arg4 string, arg5 time.Time, arg6 float32, arg7 []domain.CastMember, arg8 []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilm", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "UpdateFilm", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilm indicates an expected call of UpdateFilm.
-func (mr *MockFilmRepositoryMockRecorder) UpdateFilm(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) UpdateFilm(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockFilmRepository)(nil).UpdateFilm), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockFilmRepository)(nil).UpdateFilm), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 }
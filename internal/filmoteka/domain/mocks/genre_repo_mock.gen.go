@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/PoorMercymain/filmoteka/internal/filmoteka/domain (interfaces: GenreRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGenreRepository is a mock of GenreRepository interface.
+type MockGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepositoryMockRecorder
+}
+
+// MockGenreRepositoryMockRecorder is the mock recorder for MockGenreRepository.
+type MockGenreRepositoryMockRecorder struct {
+	mock *MockGenreRepository
+}
+
+// NewMockGenreRepository creates a new mock instance.
+func NewMockGenreRepository(ctrl *gomock.Controller) *MockGenreRepository {
+	mock := &MockGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepository) EXPECT() *MockGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGenre mocks base method.
+func (m *MockGenreRepository) CreateGenre(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockGenreRepositoryMockRecorder) CreateGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreRepository)(nil).CreateGenre), arg0, arg1)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenreRepository) DeleteGenre(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreRepositoryMockRecorder) DeleteGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreRepository)(nil).DeleteGenre), arg0, arg1)
+}
+
+// ReadGenres mocks base method.
+func (m *MockGenreRepository) ReadGenres(arg0 context.Context) ([]domain.OutputGenre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGenres", arg0)
+	ret0, _ := ret[0].([]domain.OutputGenre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadGenres indicates an expected call of ReadGenres.
+func (mr *MockGenreRepositoryMockRecorder) ReadGenres(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGenres", reflect.TypeOf((*MockGenreRepository)(nil).ReadGenres), arg0)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockGenreRepository) UpdateGenre(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreRepositoryMockRecorder) UpdateGenre(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreRepository)(nil).UpdateGenre), arg0, arg1, arg2)
+}
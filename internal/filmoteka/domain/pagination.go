@@ -0,0 +1,33 @@
+package domain
+
+// The cursor takes precedence over the page number.
+type Pagination struct {
+	Page  int
+	Limit int
+	After string
+}
+
+type PageInfo struct {
+	Total      int
+	NextCursor string
+}
+
+type FilmsPage struct {
+	Films      []OutputFilm `json:"films"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+type ActorsPage struct {
+	Actors     []OutputActor `json:"actors"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+type AuditPage struct {
+	Records    []AuditRecord `json:"records"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+type UsersPage struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+const (
+	PermissionFilmRead    = "film:read"
+	PermissionFilmCreate  = "film:create"
+	PermissionFilmUpdate  = "film:update"
+	PermissionFilmDelete  = "film:delete"
+	PermissionActorRead   = "actor:read"
+	PermissionActorCreate = "actor:create"
+	PermissionActorUpdate = "actor:update"
+	PermissionActorDelete = "actor:delete"
+	PermissionGenreRead   = "genre:read"
+	PermissionGenreCreate = "genre:create"
+	PermissionGenreUpdate = "genre:update"
+	PermissionGenreDelete = "genre:delete"
+	PermissionAuditRead   = "audit:read"
+	PermissionUserManage  = "user:manage"
+)
+
+// unlike roles, the scopes of API keys are fixed and never allow managing users or reading the audit log
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+const APIKeyLoginPrefix = "api-key:"
+
+var APIKeyScopes = map[string][]string{
+	APIKeyScopeRead: {PermissionFilmRead, PermissionActorRead, PermissionGenreRead},
+	APIKeyScopeWrite: {
+		PermissionFilmRead, PermissionFilmCreate, PermissionFilmUpdate, PermissionFilmDelete,
+		PermissionActorRead, PermissionActorCreate, PermissionActorUpdate, PermissionActorDelete,
+		PermissionGenreRead, PermissionGenreCreate, PermissionGenreUpdate, PermissionGenreDelete,
+	},
+}
+
+// Role роль
+// @Description роль пользователя и ее разрешения
+type Role struct {
+	Name        string   `json:"name" example:"editor"`
+	Permissions []string `json:"permissions" example:"film:read,film:update"`
+}
+
+// UserRole роль пользователя
+// @Description название назначаемой пользователю роли
+type UserRole struct {
+	Role string `json:"role" example:"editor"`
+}
@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -18,11 +25,12 @@ import (
 )
 
 type actor struct {
-	srv domain.ActorService
+	srv                 domain.ActorService
+	similarityThreshold float64
 }
 
-func NewActor(srv domain.ActorService) *actor {
-	return &actor{srv: srv}
+func NewActor(srv domain.ActorService, similarityThreshold float64) *actor {
+	return &actor{srv: srv, similarityThreshold: similarityThreshold}
 }
 
 // @Tags Actors
@@ -55,22 +63,7 @@ func (h *actor) CreateActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if actor.Name == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNoNameProvided, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	var gender bool
-	if actor.Gender == "male" {
-		gender = domain.Male
-	} else if actor.Gender == "female" {
-		gender = domain.Female
-	} else {
-		httperrorwriter.WriteError(w, appErrors.ErrUnknownGender, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	birthday, err := time.Parse(time.DateOnly, actor.Birthday)
+	gender, birthday, err := validateActor(actor)
 	if err != nil {
 		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
@@ -95,15 +88,18 @@ func (h *actor) CreateActor(w http.ResponseWriter, r *http.Request) {
 
 // @Tags Actors
 // @Summary Запрос обновления актера в БД
-// @Description Запрос для обновления информации об актере в БД, как полностью, так и частичного
+// @Description Запрос для замены информации об актере в БД, все поля обязательны (для частичного обновления используется PATCH)
 // @Accept json
 // @Param input body domain.Actor true "информация об актере"
 // @Param id path int true "id актера" Example(1)
+// @Param If-Match header string true "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
 // @Success 204
 // @Failure 400
 // @Failure 401
 // @Failure 403
 // @Failure 404
+// @Failure 412
+// @Failure 428
 // @Failure 500
 // @Router /actor/{id} [put]
 func (h *actor) UpdateActor(w http.ResponseWriter, r *http.Request) {
@@ -123,6 +119,17 @@ func (h *actor) UpdateActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
 	err = jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
 	if err != nil {
 		return
@@ -137,37 +144,19 @@ func (h *actor) UpdateActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if actor.Name == "" && actor.Gender == "" && actor.Birthday == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNothingProvidedInJSON, http.StatusBadRequest, logErrPrefix)
+	gender, birthday, err := validateActor(actor)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	var gender bool
-	var genderPtr *bool
-	if actor.Gender != "" {
-		if actor.Gender == "male" {
-			gender = domain.Male
-			genderPtr = &gender
-		} else if actor.Gender == "female" {
-			gender = domain.Female
-			genderPtr = &gender
-		} else {
-			httperrorwriter.WriteError(w, appErrors.ErrUnknownGender, http.StatusBadRequest, logErrPrefix)
-			return
-		}
-	}
-
-	var birthday time.Time
-	if actor.Birthday != "" {
-		birthday, err = time.Parse(time.DateOnly, actor.Birthday)
-		if err != nil {
-			httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+	err = h.srv.UpdateActor(r.Context(), id, version, actor.Name, gender, birthday)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
 			return
 		}
-	}
 
-	err = h.srv.UpdateActor(r.Context(), id, actor.Name, genderPtr, birthday)
-	if err != nil {
 		if errors.Is(err, appErrors.ErrNotFoundInDB) {
 			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
 			return
@@ -182,19 +171,24 @@ func (h *actor) UpdateActor(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Tags Actors
-// @Summary Запрос удаления актера из БД
-// @Description Запрос для удаления информации об актере из БД
+// @Summary Запрос частичного обновления актера в БД
+// @Description Запрос для частичного обновления информации об актере в БД, тело запроса - JSON Merge Patch (RFC 7396): отсутствующие поля не изменяются, null для полей актера недопустим
+// @Accept json
+// @Param input body domain.Actor true "изменяемые поля актера"
 // @Param id path int true "id актера" Example(1)
+// @Param If-Match header string true "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
 // @Success 204
 // @Failure 400
 // @Failure 401
 // @Failure 403
 // @Failure 404
+// @Failure 412
+// @Failure 428
 // @Failure 500
-// @Router /actor/{id} [delete]
-func (h *actor) DeleteActor(w http.ResponseWriter, r *http.Request) {
+// @Router /actor/{id} [patch]
+func (h *actor) PatchActor(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.DeleteActor():"
+	const logErrPrefix = "handlers.PatchActor():"
 
 	idStr := r.PathValue("id")
 
@@ -209,186 +203,177 @@ func (h *actor) DeleteActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.srv.DeleteActor(r.Context(), id)
+	version, err := ifMatch(r)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrNotFoundInDB) {
-			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
 			return
 		}
 
-		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-}
-
-// @Tags Actors
-// @Summary Запрос получения списка актеров из БД
-// @Description Запрос для получения списка актеров из БД, для каждого актера также выводится список фильмов с его участием, предусмотрена пагинация
-// @Produce json
-// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
-// @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
-// @Success 200
-// @Success 204
-// @Failure 400
-// @Failure 401
-// @Failure 500
-// @Router /actors [get]
-func (h *actor) ReadActors(w http.ResponseWriter, r *http.Request) {
-	defer r.Body.Close()
-	const logErrPrefix = "handlers.ReadActors():"
-
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-	if pageStr == "" {
-		pageStr = "1"
-	}
-
-	if limitStr == "" {
-		limitStr = "15"
-	}
-
-	page, err := strconv.Atoi(pageStr)
+	err = jsonhttpvalidator.ValidateMergePatchRequest(w, r, logErrPrefix)
 	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrPageInNotANumber, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitIsNotANumber, http.StatusBadRequest, logErrPrefix)
-		return
-	}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
 
-	if page < 1 {
-		httperrorwriter.WriteError(w, appErrors.ErrPageNumberIsTooSmall, http.StatusBadRequest, logErrPrefix)
+	var mergePatch domain.ActorMergePatch
+	if err = d.Decode(&mergePatch); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	if limit < 1 || limit > 100 {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitParameterNotInCorrectRange, http.StatusBadRequest, logErrPrefix)
+	patch, err := actorPatch(mergePatch)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	actors, err := h.srv.ReadActors(r.Context(), page, limit)
+	err = h.srv.PatchActor(r.Context(), id, version, patch)
 	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
-	if len(actors) == 0 {
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+func validateActor(actor domain.Actor) (bool, time.Time, error) {
+	if actor.Name == "" {
+		return false, time.Time{}, appErrors.ErrNoNameProvided
+	}
 
-	e := json.NewEncoder(w)
-	err = e.Encode(actors)
+	gender, err := parseGender(actor.Gender)
 	if err != nil {
-		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		return false, time.Time{}, err
 	}
-}
 
-type film struct {
-	srv domain.FilmService
-}
+	birthday, err := time.Parse(time.DateOnly, actor.Birthday)
+	if err != nil {
+		return false, time.Time{}, err
+	}
 
-func NewFilm(srv domain.FilmService) *film {
-	return &film{srv: srv}
+	return gender, birthday, nil
 }
 
-// @Tags Films
-// @Summary Запрос добавления информации о фильме в БД
-// @Description Запрос для добавления информации о фильме в БД
-// @Accept json
-// @Produce json
-// @Param input body domain.Film true "информация о фильме"
-// @Success 201
-// @Failure 400
-// @Failure 401
-// @Failure 403
-// @Failure 404
-// @Failure 500
-// @Router /film [post]
-func (h *film) CreateFilm(w http.ResponseWriter, r *http.Request) {
-	const (
-		titleLimit       = 150
-		descriptionLimit = 1000
-		minRating        = 0
-		maxRating        = 10
-	)
+func actorPatch(mergePatch domain.ActorMergePatch) (domain.ActorPatch, error) {
+	var patch domain.ActorPatch
 
-	defer r.Body.Close()
-	const logErrPrefix = "handlers.CreateFilm():"
+	if !mergePatch.Name.Set && !mergePatch.Gender.Set && !mergePatch.Birthday.Set {
+		return domain.ActorPatch{}, appErrors.ErrNothingProvidedInJSON
+	}
 
-	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
-	if err != nil {
-		return
+	if mergePatch.Name.Null || mergePatch.Gender.Null || mergePatch.Birthday.Null {
+		return domain.ActorPatch{}, appErrors.ErrRequiredFieldIsNull
 	}
 
-	d := json.NewDecoder(r.Body)
-	d.DisallowUnknownFields()
+	if mergePatch.Name.Set {
+		if mergePatch.Name.Value == "" {
+			return domain.ActorPatch{}, appErrors.ErrNoNameProvided
+		}
 
-	var film domain.Film
-	if err = d.Decode(&film); err != nil {
-		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
-		return
+		patch.Name = &mergePatch.Name.Value
 	}
 
-	if film.Title == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNoTitleProvided, http.StatusBadRequest, logErrPrefix)
-		return
+	if mergePatch.Gender.Set {
+		gender, err := parseGender(mergePatch.Gender.Value)
+		if err != nil {
+			return domain.ActorPatch{}, err
+		}
+
+		patch.Gender = &gender
 	}
 
-	if len([]rune(film.Title)) > titleLimit {
-		httperrorwriter.WriteError(w, appErrors.ErrTitleTooLong, http.StatusBadRequest, logErrPrefix)
-		return
+	if mergePatch.Birthday.Set {
+		birthday, err := time.Parse(time.DateOnly, mergePatch.Birthday.Value)
+		if err != nil {
+			return domain.ActorPatch{}, err
+		}
+
+		patch.Birthday = &birthday
 	}
 
-	if film.Description == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNoDescriptionProvided, http.StatusBadRequest, logErrPrefix)
-		return
+	return patch, nil
+}
+
+func parseGender(genderStr string) (bool, error) {
+	switch genderStr {
+	case "male":
+		return domain.Male, nil
+	case "female":
+		return domain.Female, nil
+	default:
+		return false, appErrors.ErrUnknownGender
 	}
+}
 
-	if len([]rune(film.Description)) > descriptionLimit {
-		httperrorwriter.WriteError(w, appErrors.ErrTitleTooLong, http.StatusBadRequest, logErrPrefix)
+// @Tags Actors
+// @Summary Запрос удаления актера из БД
+// @Description Запрос для удаления актера в корзину, откуда его можно восстановить или удалить окончательно
+// @Param id path int true "id актера" Example(1)
+// @Param If-Match header string true "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /actor/{id} [delete]
+func (h *actor) DeleteActor(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteActor():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	releaseDate, err := time.Parse(time.DateOnly, film.ReleaseDate)
+	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	if film.Rating == nil {
-		httperrorwriter.WriteError(w, appErrors.ErrNoRatingValue, http.StatusBadRequest, logErrPrefix)
-		return
-	}
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
 
-	if *film.Rating < minRating || *film.Rating > maxRating {
-		httperrorwriter.WriteError(w, appErrors.ErrWrongRatingValue, http.StatusBadRequest, logErrPrefix)
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	if film.Actors == nil {
-		film.Actors = make([]int, 0)
-	}
-
-	id, err := h.srv.CreateFilm(r.Context(), film.Title, film.Description, releaseDate, *film.Rating, film.Actors)
+	err = h.srv.DeleteActor(r.Context(), id, version)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
-			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusBadRequest, logErrPrefix)
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
 			return
 		}
 
-		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
-			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusNotFound, logErrPrefix)
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
 			return
 		}
 
@@ -397,39 +382,23 @@ func (h *film) CreateFilm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	e := json.NewEncoder(w)
-	err = e.Encode(domain.ID{ID: id})
-	if err != nil {
-		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-	}
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// @Tags Films
-// @Summary Запрос обновления информации о фильме
-// @Description Запрос для обновления информации о фильме, как полного, так и частичного
-// @Accept json
-// @Param input body domain.Film true "информация о фильме, если не убрать из запроса поле actorIDs, его значение заменит актеров фильма в БД"
-// @Param id path int true "id фильма" Example(1)
+// @Tags Actors
+// @Summary Запрос восстановления удаленного актера
+// @Description Запрос для восстановления актера из корзины, связи между фильмами и актерами при удалении сохраняются, поэтому восстанавливаются вместе с ним
+// @Param id path int true "id актера" Example(1)
 // @Success 204
 // @Failure 400
 // @Failure 401
 // @Failure 403
 // @Failure 404
 // @Failure 500
-// @Router /film/{id} [put]
-func (h *film) UpdateFilm(w http.ResponseWriter, r *http.Request) {
-	const (
-		titleLimit       = 150
-		descriptionLimit = 1000
-		minRating        = 0
-		maxRating        = 10
-	)
-
+// @Router /actor/{id}/restore [post]
+func (h *actor) RestoreActor(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.UpdateFilm():"
+	const logErrPrefix = "handlers.RestoreActor():"
 
 	idStr := r.PathValue("id")
 
@@ -444,63 +413,8 @@ func (h *film) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
-	if err != nil {
-		return
-	}
-
-	d := json.NewDecoder(r.Body)
-	d.DisallowUnknownFields()
-
-	var film domain.Film
-	if err = d.Decode(&film); err != nil {
-		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	if film.Actors == nil && film.Description == "" && film.Rating == nil && film.ReleaseDate == "" && film.Title == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNothingProvidedInJSON, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	if len([]rune(film.Title)) > titleLimit {
-		httperrorwriter.WriteError(w, appErrors.ErrTitleTooLong, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	if len([]rune(film.Description)) > descriptionLimit {
-		httperrorwriter.WriteError(w, appErrors.ErrTitleTooLong, http.StatusBadRequest, logErrPrefix)
-		return
-	}
-
-	var releaseDate time.Time
-	if film.ReleaseDate != "" {
-		releaseDate, err = time.Parse(time.DateOnly, film.ReleaseDate)
-		if err != nil {
-			httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
-			return
-		}
-	}
-
-	if film.Rating != nil {
-		if *film.Rating < minRating || *film.Rating > maxRating {
-			httperrorwriter.WriteError(w, appErrors.ErrWrongRatingValue, http.StatusBadRequest, logErrPrefix)
-			return
-		}
-	}
-
-	err = h.srv.UpdateFilm(r.Context(), id, film.Title, film.Description, releaseDate, film.Rating, film.Actors)
+	err = h.srv.RestoreActor(r.Context(), id)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
-			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusBadRequest, logErrPrefix)
-			return
-		}
-
-		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
-			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusNotFound, logErrPrefix)
-			return
-		}
-
 		if errors.Is(err, appErrors.ErrNotFoundInDB) {
 			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
 			return
@@ -514,20 +428,20 @@ func (h *film) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// @Tags Films
-// @Summary Запрос удаления фильма из БД
-// @Description Запрос для удаления информации о фильме из БД
-// @Param id path int true "id фильма" Example(1)
+// @Tags Actors
+// @Summary Запрос окончательного удаления актера
+// @Description Запрос для окончательного удаления актера из корзины вместе со всеми его связями, удалить можно только актера из корзины
+// @Param id path int true "id актера" Example(1)
 // @Success 204
 // @Failure 400
 // @Failure 401
 // @Failure 403
 // @Failure 404
 // @Failure 500
-// @Router /film/{id} [delete]
-func (h *film) DeleteFilm(w http.ResponseWriter, r *http.Request) {
+// @Router /actor/{id}/purge [delete]
+func (h *actor) PurgeActor(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.DeleteFilm():"
+	const logErrPrefix = "handlers.PurgeActor():"
 
 	idStr := r.PathValue("id")
 
@@ -542,7 +456,7 @@ func (h *film) DeleteFilm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.srv.DeleteFilm(r.Context(), id)
+	err = h.srv.PurgeActor(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrNotFoundInDB) {
 			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
@@ -557,86 +471,2743 @@ func (h *film) DeleteFilm(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// @Tags Films
-// @Summary Запрос получения списка фильмов из БД
-// @Description Запрос для получения списка фильмов из БД, для каждого фильма также выводится список фильмов с его участием, предусмотрена пагинация, по умолчанию сортируется по убыванию рейтинга
+// @Tags Actors
+// @Summary Запрос получения списка удаленных актеров
+// @Description Запрос для получения содержимого корзины: удаленных актеров, начиная с удаленных последними, время удаления выводится в поле deletedAt
 // @Produce json
-// @Param field query string false "поле для сортировки (release_date, rating, title, по умолчанию - rating)" Example(title)
-// @Param order query string false "поле для порядка сортировки (desc - по убыванию, asc - по возрастанию, по умолчанию - desc)" Example(desc)
 // @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Success 200
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /actors/deleted [get]
+func (h *actor) ReadDeletedActors(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadDeletedActors():"
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	actors, pageInfo, err := h.srv.ReadDeletedActors(r.Context(), pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	if len(actors) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	for i := range actors {
+		actors[i].ETag = etag(actors[i].Version, actors[i].LastModified)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	if r.URL.Query().Has("after") {
+		err = e.Encode(domain.ActorsPage{Actors: actors, NextCursor: pageInfo.NextCursor})
+	} else {
+		err = e.Encode(actors)
+	}
+
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Actors
+// @Summary Запрос получения истории изменений актера
+// @Description Запрос для получения журнала изменений актера, начиная с последних: кто и когда создал, изменил, удалил или восстановил запись и ее состояние до и после изменения. История сохраняется и после окончательного удаления
+// @Produce json
+// @Param id path int true "id актера" Example(1)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Success 200
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /actor/{id}/history [get]
+func (h *actor) ReadActorHistory(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadActorHistory():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	records, pageInfo, err := h.srv.ReadActorHistory(r.Context(), id, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	if len(records) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	if r.URL.Query().Has("after") {
+		err = e.Encode(domain.AuditPage{Records: records, NextCursor: pageInfo.NextCursor})
+	} else {
+		err = e.Encode(records)
+	}
+
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Actors
+// @Summary Запрос возврата актера к предыдущей версии
+// @Description Запрос для возврата актера к состоянию после изменения из истории (revision - id записи из /actor/{id}/history). Если вернуть состояние нельзя (например, актер родился бы позже выхода фильма, в котором он снимался), возвращается 409
+// @Param id path int true "id актера" Example(1)
+// @Param revision path int true "id записи истории изменений" Example(1)
+// @Param If-Match header string true "ETag актера, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /actor/{id}/revert/{revision} [post]
+func (h *actor) RevertActor(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.RevertActor():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	revisionStr := r.PathValue("revision")
+
+	if revisionStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoRevisionProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrRevisionIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.RevertActor(r.Context(), id, version, revision)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrRevisionNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrRevisionNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Actors
+// @Summary Запрос получения актера из БД
+// @Description Запрос для получения информации об актере из БД вместе со списком фильмов с его участием
+// @Produce json
+// @Param id path int true "id актера" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200
+// @Success 304
+// @Header 200,304 {string} Last-Modified "время последнего изменения"
+// @Header 200,304 {string} ETag "версия актера для If-Match, также используется в If-None-Match"
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /actor/{id} [get]
+func (h *actor) ReadActor(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadActor():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	actor, err := h.srv.ReadActor(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	actor.ETag = etag(actor.Version, actor.LastModified)
+	writeCacheableJSON(w, r, actor, actor.ETag, actor.LastModified, logErrPrefix)
+}
+
+// @Tags Actors
+// @Summary Запрос получения списка актеров из БД
+// @Description Запрос для получения списка актеров из БД, для каждого актера также выводится список фильмов с его участием, предусмотрена сортировка и пагинация
+// @Produce json
+// @Param field query string false "поле для сортировки, возможные значения: name, birthday, film_count (по умолчанию name)" Example(birthday)
+// @Param order query string false "порядок сортировки, возможные значения: asc, desc (по умолчанию asc)" Example(desc)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
 // @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200
+// @Success 304
+// @Header 200,304 {string} ETag "идентификатор содержимого страницы"
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
 // @Success 204
 // @Failure 400
 // @Failure 401
 // @Failure 500
-// @Router /films [get]
-func (h *film) ReadFilms(w http.ResponseWriter, r *http.Request) {
+// @Router /actors [get]
+func (h *actor) ReadActors(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.ReadFilms():"
+	const logErrPrefix = "handlers.ReadActors():"
 
 	field := r.URL.Query().Get("field")
 	order := r.URL.Query().Get("order")
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
 	if field == "" {
-		field = "rating"
+		field = "name"
 	}
 
 	if order == "" {
-		order = "desc"
+		order = "asc"
+	}
+
+	if field != "name" && field != "birthday" && field != "film_count" {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownSortField, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if order != "desc" && order != "asc" {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownOrder, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	actors, pageInfo, err := h.srv.ReadActors(r.Context(), field, order, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	for i := range actors {
+		actors[i].ETag = etag(actors[i].Version, actors[i].LastModified)
+	}
+
+	if len(actors) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var body any = actors
+	if r.URL.Query().Has("after") {
+		body = domain.ActorsPage{Actors: actors, NextCursor: pageInfo.NextCursor}
+	}
+
+	writeCacheableJSON(w, r, body, "", time.Time{}, logErrPrefix)
+}
+
+// @Tags Actors
+// @Summary Запрос поиска актеров в БД
+// @Description Запрос для поиска актеров в БД по фрагменту имени, полу, диапазону дат рождения и/или фрагменту названия фильма, в котором снимался актер, для успешного запроса надо указать хотя бы один из параметров поиска. Для каждого актера также выводится список фильмов с его участием, предусмотрена пагинация. По умолчанию актеры упорядочены по имени, в нечетком режиме (fuzzy=true) имя сравнивается по триграммам с учетом опечаток, для каждого актера выводится степень сходства (similarity), актеры упорядочены по ее убыванию
+// @Produce json
+// @Param name query string false "фрагмент имени актера для поиска" Example(Val)
+// @Param gender query string false "пол актера, возможные значения: male, female" Example(female)
+// @Param bornFrom query string false "минимальная дата рождения в формате YYYY-MM-DD" Example(1970-01-01)
+// @Param bornTo query string false "максимальная дата рождения в формате YYYY-MM-DD" Example(1999-12-31)
+// @Param film query string false "фрагмент названия фильма, в котором снимался актер" Example(film)
+// @Param fuzzy query bool false "нечеткий поиск по триграммам (по умолчанию false)" Example(true)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200
+// @Success 304
+// @Header 200,304 {string} ETag "идентификатор содержимого страницы"
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /actors/search [get]
+func (h *actor) FindActors(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.FindActors():"
+
+	fuzzy, err := parseFuzzy(r.URL.Query().Get("fuzzy"))
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	var gender *bool
+	if genderStr := r.URL.Query().Get("gender"); genderStr != "" {
+		parsedGender, err := parseGender(genderStr)
+		if err != nil {
+			httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		gender = &parsedGender
+	}
+
+	bornFrom, err := parseDate(r.URL.Query().Get("bornFrom"))
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	bornTo, err := parseDate(r.URL.Query().Get("bornTo"))
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if !bornFrom.IsZero() && !bornTo.IsZero() && bornFrom.After(bornTo) {
+		httperrorwriter.WriteError(w, appErrors.ErrWrongDateRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	search := domain.ActorSearch{
+		Name:      r.URL.Query().Get("name"),
+		Gender:    gender,
+		BornFrom:  bornFrom,
+		BornTo:    bornTo,
+		FilmTitle: r.URL.Query().Get("film"),
+		Fuzzy:     fuzzy,
+		Threshold: h.similarityThreshold,
+	}
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if search.Name == "" && search.Gender == nil && search.BornFrom.IsZero() && search.BornTo.IsZero() && search.FilmTitle == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoSearchCriteriaProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	actors, pageInfo, err := h.srv.FindActors(r.Context(), search, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	for i := range actors {
+		actors[i].ETag = etag(actors[i].Version, actors[i].LastModified)
+	}
+
+	if len(actors) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var body any = actors
+	if r.URL.Query().Has("after") {
+		body = domain.ActorsPage{Actors: actors, NextCursor: pageInfo.NextCursor}
+	}
+
+	writeCacheableJSON(w, r, body, "", time.Time{}, logErrPrefix)
+}
+
+func parsePagination(r *http.Request, defaultLimit int) (domain.Pagination, error) {
+	pagination := domain.Pagination{Page: 1, Limit: defaultLimit, After: r.URL.Query().Get("after")}
+
+	if r.URL.Query().Has("page") && r.URL.Query().Has("after") {
+		return domain.Pagination{}, appErrors.ErrPageAndCursorProvided
+	}
+
+	var err error
+	pageStr := r.URL.Query().Get("page")
+	if pageStr != "" {
+		pagination.Page, err = strconv.Atoi(pageStr)
+		if err != nil {
+			return domain.Pagination{}, appErrors.ErrPageInNotANumber
+		}
+	}
+
+	limitStr := r.URL.Query().Get("limit")
+	if limitStr != "" {
+		pagination.Limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return domain.Pagination{}, appErrors.ErrLimitIsNotANumber
+		}
+	}
+
+	if pagination.Page < 1 {
+		return domain.Pagination{}, appErrors.ErrPageNumberIsTooSmall
+	}
+
+	if pagination.Limit < 1 || pagination.Limit > 100 {
+		return domain.Pagination{}, appErrors.ErrLimitParameterNotInCorrectRange
+	}
+
+	return pagination, nil
+}
+
+func etag(version int, lastModified time.Time) string {
+	return `"` + strconv.Itoa(version) + "-" + strconv.FormatInt(lastModified.UnixMicro(), 36) + `"`
+}
+
+// ifMatch returns 0 if the If-Match header is *, so any version matches.
+func ifMatch(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, appErrors.ErrNoIfMatchProvided
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, appErrors.ErrWrongIfMatch
+	}
+
+	versionStr, _, _ := strings.Cut(header[1:len(header)-1], "-")
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		return 0, appErrors.ErrWrongIfMatch
+	}
+
+	return version, nil
+}
+
+// Lists are written with a zero lastModified, since the latest change of the records on a page stays the same when a record leaves the page.
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, v any, etag string, lastModified time.Time, logErrPrefix string) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	body = append(body, '\n')
+
+	if etag == "" {
+		hash := sha256.Sum256(append([]byte(w.Header().Get("X-Total-Count")+"\n"), body...))
+		etag = `"` + hex.EncodeToString(hash[:16]) + `"`
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write(body)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+func writePaginationHeaders(w http.ResponseWriter, r *http.Request, pagination domain.Pagination, pageInfo domain.PageInfo) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(pageInfo.Total))
+
+	link := func(rel string, param string, value string) string {
+		query := r.URL.Query()
+		query.Del("page")
+		query.Del("after")
+		query.Set("limit", strconv.Itoa(pagination.Limit))
+		query.Set(param, value)
+
+		return fmt.Sprintf("<%s?%s>; rel=\"%s\"", r.URL.Path, query.Encode(), rel)
+	}
+
+	var links []string
+	if r.URL.Query().Has("after") {
+		links = append(links, link("first", "after", ""))
+		if pageInfo.NextCursor != "" {
+			links = append(links, link("next", "after", pageInfo.NextCursor))
+		}
+	} else {
+		lastPage := (pageInfo.Total + pagination.Limit - 1) / pagination.Limit
+		if lastPage < 1 {
+			lastPage = 1
+		}
+
+		links = append(links, link("first", "page", "1"))
+		if pagination.Page > 1 {
+			links = append(links, link("prev", "page", strconv.Itoa(min(pagination.Page-1, lastPage))))
+		}
+
+		if pageInfo.NextCursor != "" {
+			links = append(links, link("next", "page", strconv.Itoa(pagination.Page+1)))
+		}
+
+		links = append(links, link("last", "page", strconv.Itoa(lastPage)))
+	}
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+func parseDate(dateStr string) (time.Time, error) {
+	if dateStr == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, dateStr)
+	if err != nil {
+		return time.Time{}, appErrors.ErrWrongDateFormat
+	}
+
+	return date, nil
+}
+
+func filmFilter(r *http.Request) (domain.FilmFilter, error) {
+	var (
+		filter domain.FilmFilter
+		err    error
+	)
+
+	filter.Genre = r.URL.Query().Get("genre")
+
+	filter.ReleasedFrom, err = parseDate(r.URL.Query().Get("releasedFrom"))
+	if err != nil {
+		return domain.FilmFilter{}, err
+	}
+
+	filter.ReleasedTo, err = parseDate(r.URL.Query().Get("releasedTo"))
+	if err != nil {
+		return domain.FilmFilter{}, err
+	}
+
+	if !filter.ReleasedFrom.IsZero() && !filter.ReleasedTo.IsZero() && filter.ReleasedFrom.After(filter.ReleasedTo) {
+		return domain.FilmFilter{}, appErrors.ErrWrongDateRange
+	}
+
+	filter.MinRating, err = parseRating(r.URL.Query().Get("minRating"))
+	if err != nil {
+		return domain.FilmFilter{}, err
+	}
+
+	filter.MaxRating, err = parseRating(r.URL.Query().Get("maxRating"))
+	if err != nil {
+		return domain.FilmFilter{}, err
+	}
+
+	if filter.MinRating != nil && filter.MaxRating != nil && *filter.MinRating > *filter.MaxRating {
+		return domain.FilmFilter{}, appErrors.ErrWrongRatingRange
+	}
+
+	actorIDStr := r.URL.Query().Get("actorID")
+	if actorIDStr != "" {
+		filter.ActorID, err = strconv.Atoi(actorIDStr)
+		if err != nil || filter.ActorID < 1 {
+			return domain.FilmFilter{}, appErrors.ErrActorIDIsNotANumber
+		}
+	}
+
+	return filter, nil
+}
+
+func parseRating(ratingStr string) (*float32, error) {
+	if ratingStr == "" {
+		return nil, nil
+	}
+
+	rating, err := strconv.ParseFloat(ratingStr, 32)
+	if err != nil {
+		return nil, appErrors.ErrRatingIsNotANumber
+	}
+
+	// written this way so NaN is rejected too
+	if !(rating >= 0 && rating <= 10) {
+		return nil, appErrors.ErrWrongRatingValue
+	}
+
+	res := float32(rating)
+	return &res, nil
+}
+
+func parseFuzzy(fuzzyStr string) (bool, error) {
+	if fuzzyStr == "" {
+		return false, nil
+	}
+
+	fuzzy, err := strconv.ParseBool(fuzzyStr)
+	if err != nil {
+		return false, appErrors.ErrFuzzyIsNotABoolean
+	}
+
+	return fuzzy, nil
+}
+
+const (
+	titleLimit       = 150
+	descriptionLimit = 1000
+	minRating        = 0
+	maxRating        = 10
+)
+
+type film struct {
+	srv                 domain.FilmService
+	similarityThreshold float64
+}
+
+func NewFilm(srv domain.FilmService, similarityThreshold float64) *film {
+	return &film{srv: srv, similarityThreshold: similarityThreshold}
+}
+
+// @Tags Films
+// @Summary Запрос добавления информации о фильме в БД
+// @Description Запрос для добавления информации о фильме в БД
+// @Accept json
+// @Produce json
+// @Param input body domain.Film true "информация о фильме, актеры указываются либо списком actorIDs, либо списком cast с ролями"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /film [post]
+func (h *film) CreateFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CreateFilm():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var film domain.Film
+	if err = d.Decode(&film); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	releaseDate, cast, err := validateFilm(film)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if film.Genres == nil {
+		film.Genres = make([]int, 0)
+	}
+
+	id, err := h.srv.CreateFilm(r.Context(), film.Title, film.Description, releaseDate, *film.Rating, cast, film.Genres)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrGenreDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	e := json.NewEncoder(w)
+	err = e.Encode(domain.ID{ID: id})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Films
+// @Summary Запрос обновления информации о фильме
+// @Description Запрос для замены информации о фильме, все поля, кроме actorIDs (или cast) и genres, обязательны (для частичного обновления используется PATCH)
+// @Accept json
+// @Param input body domain.Film true "информация о фильме, отсутствующие actorIDs (или cast) и genres означают, что у фильма не будет актеров и жанров"
+// @Param id path int true "id фильма" Example(1)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id} [put]
+func (h *film) UpdateFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.UpdateFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var film domain.Film
+	if err = d.Decode(&film); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	releaseDate, cast, err := validateFilm(film)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if film.Genres == nil {
+		film.Genres = make([]int, 0)
+	}
+
+	err = h.srv.UpdateFilm(r.Context(), id, version, film.Title, film.Description, releaseDate, *film.Rating, cast, film.Genres)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrGenreDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос частичного обновления информации о фильме
+// @Description Запрос для частичного обновления информации о фильме, тело запроса - JSON Merge Patch (RFC 7396): отсутствующие поля не изменяются, null удаляет описание, актеров (actorIDs или cast) или жанры фильма, для остальных полей null недопустим
+// @Accept json
+// @Param input body domain.Film true "изменяемые поля фильма"
+// @Param id path int true "id фильма" Example(1)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id} [patch]
+func (h *film) PatchFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.PatchFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = jsonhttpvalidator.ValidateMergePatchRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var mergePatch domain.FilmMergePatch
+	if err = d.Decode(&mergePatch); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	patch, err := filmPatch(mergePatch)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.PatchFilm(r.Context(), id, version, patch)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrGenreDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос удаления фильма из БД
+// @Description Запрос для удаления фильма в корзину, откуда его можно восстановить или удалить окончательно
+// @Param id path int true "id фильма" Example(1)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id} [delete]
+func (h *film) DeleteFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteFilm(r.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос восстановления удаленного фильма
+// @Description Запрос для восстановления фильма из корзины, связи между фильмами и актерами при удалении сохраняются, поэтому восстанавливаются вместе с ним
+// @Param id path int true "id фильма" Example(1)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /film/{id}/restore [post]
+func (h *film) RestoreFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.RestoreFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.RestoreFilm(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос окончательного удаления фильма
+// @Description Запрос для окончательного удаления фильма из корзины вместе со всеми его связями, удалить можно только фильм из корзины
+// @Param id path int true "id фильма" Example(1)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /film/{id}/purge [delete]
+func (h *film) PurgeFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.PurgeFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.PurgeFilm(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос получения списка удаленных фильмов
+// @Description Запрос для получения содержимого корзины: удаленных фильмов, начиная с удаленных последними, время удаления выводится в поле deletedAt
+// @Produce json
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Success 200
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /films/deleted [get]
+func (h *film) ReadDeletedFilms(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadDeletedFilms():"
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	films, pageInfo, err := h.srv.ReadDeletedFilms(r.Context(), pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	if len(films) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	for i := range films {
+		films[i].ETag = etag(films[i].Version, films[i].LastModified)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	if r.URL.Query().Has("after") {
+		err = e.Encode(domain.FilmsPage{Films: films, NextCursor: pageInfo.NextCursor})
+	} else {
+		err = e.Encode(films)
+	}
+
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Films
+// @Summary Запрос получения истории изменений фильма
+// @Description Запрос для получения журнала изменений фильма, начиная с последних: кто и когда создал, изменил, удалил или восстановил запись и ее состояние до и после изменения. История сохраняется и после окончательного удаления
+// @Produce json
+// @Param id path int true "id фильма" Example(1)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Success 200
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /film/{id}/history [get]
+func (h *film) ReadFilmHistory(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadFilmHistory():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	records, pageInfo, err := h.srv.ReadFilmHistory(r.Context(), id, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	if len(records) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	if r.URL.Query().Has("after") {
+		err = e.Encode(domain.AuditPage{Records: records, NextCursor: pageInfo.NextCursor})
+	} else {
+		err = e.Encode(records)
+	}
+
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Films
+// @Summary Запрос возврата фильма к предыдущей версии
+// @Description Запрос для возврата фильма вместе с актерами, съемочной группой и жанрами к состоянию после изменения из истории (revision - id записи из /film/{id}/history). Если вернуть состояние нельзя (например, актер из него окончательно удален, жанр удален или актер родился позже даты выхода фильма), возвращается 409
+// @Param id path int true "id фильма" Example(1)
+// @Param revision path int true "id записи истории изменений" Example(1)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id}/revert/{revision} [post]
+func (h *film) RevertFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.RevertFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	revisionStr := r.PathValue("revision")
+
+	if revisionStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoRevisionProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrRevisionIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.RevertFilm(r.Context(), id, version, revision)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrRevisionNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrRevisionNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorDoesNotExist, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrPersonDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrPersonDoesNotExist, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrGenreDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreDoesNotExist, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrActorNotBornBeforeFilmRelease) {
+			httperrorwriter.WriteError(w, appErrors.ErrActorNotBornBeforeFilmRelease, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Films
+// @Summary Запрос получения фильма из БД
+// @Description Запрос для получения информации о фильме из БД вместе со списком актеров, сыгравших в нем
+// @Produce json
+// @Param id path int true "id фильма" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200
+// @Success 304
+// @Header 200,304 {string} Last-Modified "время последнего изменения"
+// @Header 200,304 {string} ETag "версия фильма для If-Match, также используется в If-None-Match"
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /film/{id} [get]
+func (h *film) ReadFilm(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadFilm():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	film, err := h.srv.ReadFilm(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	film.ETag = etag(film.Version, film.LastModified)
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, logErrPrefix)
+}
+
+// @Tags Films
+// @Summary Запрос получения списка фильмов из БД
+// @Description Запрос для получения списка фильмов из БД, для каждого фильма также выводится список фильмов с его участием, предусмотрена фильтрация по жанру, дате выхода, рейтингу и актеру, а также пагинация, по умолчанию сортируется по убыванию рейтинга
+// @Produce json
+// @Param field query string false "поле для сортировки (release_date, rating, title, по умолчанию - rating)" Example(title)
+// @Param order query string false "поле для порядка сортировки (desc - по убыванию, asc - по возрастанию, по умолчанию - desc)" Example(desc)
+// @Param genre query string false "название жанра, фильмы которого нужно вывести" Example(drama)
+// @Param releasedFrom query string false "минимальная дата выхода в формате YYYY-MM-DD" Example(1990-01-01)
+// @Param releasedTo query string false "максимальная дата выхода в формате YYYY-MM-DD" Example(1999-12-31)
+// @Param minRating query number false "минимальный рейтинг, в диапазоне [0, 10]" Example(8)
+// @Param maxRating query number false "максимальный рейтинг, в диапазоне [0, 10]" Example(10)
+// @Param actorID query int false "id актера, фильмы с участием которого нужно вывести" Example(1)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200
+// @Success 304
+// @Header 200,304 {string} ETag "идентификатор содержимого страницы"
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /films [get]
+func (h *film) ReadFilms(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadFilms():"
+
+	field := r.URL.Query().Get("field")
+	order := r.URL.Query().Get("order")
+	if field == "" {
+		field = "rating"
+	}
+
+	if order == "" {
+		order = "desc"
+	}
+
+	if field != "title" && field != "rating" && field != "release_date" {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownSortField, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if order != "desc" && order != "asc" {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownOrder, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	pagination, err := parsePagination(r, 15)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	filter, err := filmFilter(r)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	films, pageInfo, err := h.srv.ReadFilms(r.Context(), field, order, filter, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	for i := range films {
+		films[i].ETag = etag(films[i].Version, films[i].LastModified)
+	}
+
+	if len(films) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var body any = films
+	if r.URL.Query().Has("after") {
+		body = domain.FilmsPage{Films: films, NextCursor: pageInfo.NextCursor}
+	}
+
+	writeCacheableJSON(w, r, body, "", time.Time{}, logErrPrefix)
+}
+
+// @Tags Films
+// @Summary Запрос поиска фильмов в БД
+// @Description Запрос для поиска фильмов в БД по тексту (по названию и описанию, с учетом морфологии русского и английского языков), фрагменту названия фильма, имени актера и/или имени режиссера, по умолчанию выдает 1 самый подходящий фильм, для успешного запроса надо указать хотя бы один из параметров поиска. При поиске по тексту фильмы упорядочены по релевантности, иначе - по убыванию рейтинга. В нечетком режиме (fuzzy=true) название и имена сравниваются по триграммам с учетом опечаток, для каждого фильма выводится степень сходства (similarity), фильмы упорядочены по ее убыванию
+// @Produce json
+// @Param q query string false "текст для полнотекстового поиска по названию и описанию фильма" Example(космос)
+// @Param title query string false "фрагмент названия фильма для поиска" Example(film)
+// @Param name query string false "фрагмент имени актера для поиска" Example(Val)
+// @Param director query string false "фрагмент имени режиссера для поиска" Example(Nik)
+// @Param genre query string false "название жанра, среди фильмов которого производится поиск" Example(drama)
+// @Param fuzzy query bool false "нечеткий поиск по триграммам (по умолчанию false)" Example(true)
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 1)" Example(1)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200
+// @Success 304
+// @Header 200,304 {string} ETag "идентификатор содержимого страницы"
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /films/search [get]
+func (h *film) FindFilms(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.FindFilms():"
+
+	fuzzy, err := parseFuzzy(r.URL.Query().Get("fuzzy"))
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	search := domain.FilmSearch{
+		Query:        r.URL.Query().Get("q"),
+		Title:        r.URL.Query().Get("title"),
+		ActorName:    r.URL.Query().Get("name"),
+		DirectorName: r.URL.Query().Get("director"),
+		Genre:        r.URL.Query().Get("genre"),
+		Fuzzy:        fuzzy,
+		Threshold:    h.similarityThreshold,
+	}
+
+	pagination, err := parsePagination(r, 1)
+	if err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if search.Query == "" && search.Title == "" && search.ActorName == "" && search.DirectorName == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoFragmentsProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	films, pageInfo, err := h.srv.FindFilms(r.Context(), search, pagination)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	for i := range films {
+		films[i].ETag = etag(films[i].Version, films[i].LastModified)
+	}
+
+	if len(films) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var body any = films
+	if r.URL.Query().Has("after") {
+		body = domain.FilmsPage{Films: films, NextCursor: pageInfo.NextCursor}
+	}
+
+	writeCacheableJSON(w, r, body, "", time.Time{}, logErrPrefix)
+}
+
+// @Tags Films
+// @Summary Запрос добавления человека в съемочную группу фильма
+// @Description Запрос для указания человека из списка актеров в качестве члена съемочной группы фильма (режиссер, сценарист, продюсер, композитор, оператор, монтажер)
+// @Accept json
+// @Param input body domain.CrewCredit true "человек и его должность"
+// @Param id path int true "id фильма" Example(1)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id}/crew [post]
+func (h *film) AddCrewCredit(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.AddCrewCredit():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var credit domain.CrewCredit
+	if err = d.Decode(&credit); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if credit.PersonID == 0 {
+		httperrorwriter.WriteError(w, appErrors.ErrNoPersonIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if !isKnownJob(credit.Job) {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownJob, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.AddCrewCredit(r.Context(), id, version, credit.PersonID, credit.Job)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrPersonDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrPersonDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrCrewCreditAlreadyExists) {
+			httperrorwriter.WriteError(w, appErrors.ErrCrewCreditAlreadyExists, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// @Tags Films
+// @Summary Запрос удаления человека из съемочной группы фильма
+// @Description Запрос для удаления должности человека в съемочной группе фильма
+// @Param id path int true "id фильма" Example(1)
+// @Param personID path int true "id человека" Example(1)
+// @Param job path string true "должность (director, writer, producer, composer, cinematographer, editor)" Example(director)
+// @Param If-Match header string true "ETag фильма, изменение выполняется, только если он совпадает с текущим; * - без проверки версии"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 412
+// @Failure 428
+// @Failure 500
+// @Router /film/{id}/crew/{personID}/{job} [delete]
+func (h *film) DeleteCrewCredit(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteCrewCredit():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	personIDStr := r.PathValue("personID")
+
+	if personIDStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoPersonIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	personID, err := strconv.Atoi(personIDStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrPersonIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	job := r.PathValue("job")
+	if !isKnownJob(job) {
+		httperrorwriter.WriteError(w, appErrors.ErrUnknownJob, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNoIfMatchProvided) {
+			httperrorwriter.WriteError(w, err, http.StatusPreconditionRequired, logErrPrefix)
+			return
+		}
+
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteCrewCredit(r.Context(), id, version, personID, job)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrVersionMismatch) {
+			httperrorwriter.WriteError(w, appErrors.ErrVersionMismatch, http.StatusPreconditionFailed, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validateFilm(film domain.Film) (time.Time, []domain.CastMember, error) {
+	if film.Title == "" {
+		return time.Time{}, nil, appErrors.ErrNoTitleProvided
+	}
+
+	if len([]rune(film.Title)) > titleLimit {
+		return time.Time{}, nil, appErrors.ErrTitleTooLong
+	}
+
+	if film.Description == "" {
+		return time.Time{}, nil, appErrors.ErrNoDescriptionProvided
+	}
+
+	if len([]rune(film.Description)) > descriptionLimit {
+		return time.Time{}, nil, appErrors.ErrDescriptionTooLong
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, film.ReleaseDate)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	if film.Rating == nil {
+		return time.Time{}, nil, appErrors.ErrNoRatingValue
+	}
+
+	if *film.Rating < minRating || *film.Rating > maxRating {
+		return time.Time{}, nil, appErrors.ErrWrongRatingValue
+	}
+
+	cast, err := castFromFilm(film)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	if cast == nil {
+		cast = make([]domain.CastMember, 0)
+	}
+
+	return releaseDate, cast, nil
+}
+
+func filmPatch(mergePatch domain.FilmMergePatch) (domain.FilmPatch, error) {
+	var patch domain.FilmPatch
+
+	if !mergePatch.Title.Set && !mergePatch.Description.Set && !mergePatch.ReleaseDate.Set && !mergePatch.Rating.Set &&
+		!mergePatch.Actors.Set && !mergePatch.Cast.Set && !mergePatch.Genres.Set {
+		return domain.FilmPatch{}, appErrors.ErrNothingProvidedInJSON
+	}
+
+	if mergePatch.Title.Null || mergePatch.ReleaseDate.Null || mergePatch.Rating.Null {
+		return domain.FilmPatch{}, appErrors.ErrRequiredFieldIsNull
+	}
+
+	if mergePatch.Title.Set {
+		if mergePatch.Title.Value == "" {
+			return domain.FilmPatch{}, appErrors.ErrNoTitleProvided
+		}
+
+		if len([]rune(mergePatch.Title.Value)) > titleLimit {
+			return domain.FilmPatch{}, appErrors.ErrTitleTooLong
+		}
+
+		patch.Title = &mergePatch.Title.Value
+	}
+
+	// null leaves the value empty, so the description is removed
+	if mergePatch.Description.Set {
+		if len([]rune(mergePatch.Description.Value)) > descriptionLimit {
+			return domain.FilmPatch{}, appErrors.ErrDescriptionTooLong
+		}
+
+		patch.Description = &mergePatch.Description.Value
+	}
+
+	if mergePatch.ReleaseDate.Set {
+		releaseDate, err := time.Parse(time.DateOnly, mergePatch.ReleaseDate.Value)
+		if err != nil {
+			return domain.FilmPatch{}, err
+		}
+
+		patch.ReleaseDate = &releaseDate
+	}
+
+	if mergePatch.Rating.Set {
+		if mergePatch.Rating.Value < minRating || mergePatch.Rating.Value > maxRating {
+			return domain.FilmPatch{}, appErrors.ErrWrongRatingValue
+		}
+
+		patch.Rating = &mergePatch.Rating.Value
+	}
+
+	if mergePatch.Actors.Set && mergePatch.Cast.Set {
+		return domain.FilmPatch{}, appErrors.ErrActorIDsAndCastProvided
+	}
+
+	if mergePatch.Actors.Set || mergePatch.Cast.Set {
+		cast, err := castFromFilm(domain.Film{Actors: mergePatch.Actors.Value, Cast: mergePatch.Cast.Value})
+		if err != nil {
+			return domain.FilmPatch{}, err
+		}
+
+		if cast == nil {
+			cast = make([]domain.CastMember, 0)
+		}
+
+		patch.Cast = cast
+	}
+
+	if mergePatch.Genres.Set {
+		patch.Genres = mergePatch.Genres.Value
+		if patch.Genres == nil {
+			patch.Genres = make([]int, 0)
+		}
+	}
+
+	return patch, nil
+}
+
+func isKnownJob(job string) bool {
+	switch job {
+	case domain.JobDirector, domain.JobWriter, domain.JobProducer, domain.JobComposer, domain.JobCinematographer, domain.JobEditor:
+		return true
+	default:
+		return false
+	}
+}
+
+func castFromFilm(film domain.Film) ([]domain.CastMember, error) {
+	const characterLimit = 150
+
+	if film.Actors != nil && film.Cast != nil {
+		return nil, appErrors.ErrActorIDsAndCastProvided
+	}
+
+	if film.Actors != nil {
+		film.Cast = make([]domain.CastMember, 0, len(film.Actors))
+		for _, actorID := range film.Actors {
+			film.Cast = append(film.Cast, domain.CastMember{ActorID: actorID})
+		}
+	}
+
+	if film.Cast == nil {
+		return nil, nil
+	}
+
+	cast := make([]domain.CastMember, 0, len(film.Cast))
+	mentioned := make(map[int]struct{}, len(film.Cast))
+	for i, castMember := range film.Cast {
+		if _, ok := mentioned[castMember.ActorID]; ok {
+			return nil, appErrors.ErrActorMentionedTwice
+		}
+
+		mentioned[castMember.ActorID] = struct{}{}
+
+		if castMember.Billing < 0 {
+			return nil, appErrors.ErrWrongBillingValue
+		}
+
+		if castMember.Billing == 0 {
+			castMember.Billing = i + 1
+		}
+
+		if castMember.Role != "" && castMember.Role != domain.RoleLead && castMember.Role != domain.RoleSupporting && castMember.Role != domain.RoleCameo && castMember.Role != domain.RoleVoice {
+			return nil, appErrors.ErrUnknownRole
+		}
+
+		if len([]rune(castMember.Character)) > characterLimit {
+			return nil, appErrors.ErrCharacterNameTooLong
+		}
+
+		cast = append(cast, castMember)
+	}
+
+	return cast, nil
+}
+
+type genre struct {
+	srv domain.GenreService
+}
+
+func NewGenre(srv domain.GenreService) *genre {
+	return &genre{srv: srv}
+}
+
+// @Tags Genres
+// @Summary Запрос добавления жанра в БД
+// @Description Запрос для добавления жанра в БД
+// @Accept json
+// @Produce json
+// @Param input body domain.Genre true "информация о жанре"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Failure 500
+// @Router /genre [post]
+func (h *genre) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	const nameLimit = 50
+
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CreateGenre():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var genre domain.Genre
+	if err = d.Decode(&genre); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if genre.Name == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoNameProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if len([]rune(genre.Name)) > nameLimit {
+		httperrorwriter.WriteError(w, appErrors.ErrGenreNameTooLong, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := h.srv.CreateGenre(r.Context(), genre.Name)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrGenreAlreadyExists) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreAlreadyExists, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	e := json.NewEncoder(w)
+	err = e.Encode(domain.ID{ID: id})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+// @Tags Genres
+// @Summary Запрос переименования жанра
+// @Description Запрос для изменения названия жанра в БД
+// @Accept json
+// @Param input body domain.Genre true "информация о жанре"
+// @Param id path int true "id жанра" Example(1)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /genre/{id} [put]
+func (h *genre) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	const nameLimit = 50
+
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.UpdateGenre():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var genre domain.Genre
+	if err = d.Decode(&genre); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if genre.Name == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoNameProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if len([]rune(genre.Name)) > nameLimit {
+		httperrorwriter.WriteError(w, appErrors.ErrGenreNameTooLong, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.UpdateGenre(r.Context(), id, genre.Name)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrGenreAlreadyExists) {
+			httperrorwriter.WriteError(w, appErrors.ErrGenreAlreadyExists, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Genres
+// @Summary Запрос удаления жанра из БД
+// @Description Запрос для удаления жанра из БД, у фильмов этого жанра он также будет удален
+// @Param id path int true "id жанра" Example(1)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /genre/{id} [delete]
+func (h *genre) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteGenre():"
+
+	idStr := r.PathValue("id")
+
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteGenre(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFoundInDB) {
+			httperrorwriter.WriteError(w, appErrors.ErrNotFoundInDB, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Genres
+// @Summary Запрос получения списка жанров из БД
+// @Description Запрос для получения списка всех жанров из БД, отсортированного по названию
+// @Produce json
+// @Success 200
+// @Success 204
+// @Failure 401
+// @Failure 500
+// @Router /genres [get]
+func (h *genre) ReadGenres(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadGenres():"
+
+	genres, err := h.srv.ReadGenres(r.Context())
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	if len(genres) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	err = e.Encode(genres)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+type authorization struct {
+	srv                   domain.AuthorizationService
+	JWTKey                string
+	accessTokenTTL        time.Duration
+	refreshTokenTTL       time.Duration
+	passwordResetTokenTTL time.Duration
+}
+
+func NewAuthorization(srv domain.AuthorizationService, jwtKey string, accessTokenTTL time.Duration, refreshTokenTTL time.Duration, passwordResetTokenTTL time.Duration) *authorization {
+	return &authorization{srv: srv, JWTKey: jwtKey, accessTokenTTL: accessTokenTTL, refreshTokenTTL: refreshTokenTTL, passwordResetTokenTTL: passwordResetTokenTTL}
+}
+
+// @Tags Auth
+// @Summary Запрос регистрации в filmoteka
+// @Description Запрос для регистрации в сервисе, производится регистрация обычного пользователя (с ролью viewer; роль первого администратора нужно задать в БД в поле role таблицы auth, остальным пользователям роли назначают администраторы через /user/{login}/role), логин и пароль должны соответствовать политике (длина и допустимые символы логина, длина пароля, наличие заглавных и строчных букв и цифр, пароль не должен совпадать с логином или быть распространенным), иначе в ответе 400 перечисляются все нарушенные правила, и выдается короткоживущий JWT (можно указать в заголовке Authorization) вместе с токеном обновления для /token/refresh (оба также записываются в Cookie)
+// @Accept json
+// @Produce json
+// @Param input body domain.AuthorizationData true "аутентификационные данные"
+// @Success 201
+// @Failure 400
+// @Failure 409
+// @Failure 500
+// @Router /register [post]
+func (h *authorization) Register(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.Register():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var authData domain.AuthorizationData
+	if err = d.Decode(&authData); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.Register(r.Context(), authData.Login, authData.Password)
+	if err != nil {
+		var policyErr *appErrors.PolicyError
+		if errors.As(err, &policyErr) {
+			httperrorwriter.WriteError(w, policyErr, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrAlreadyRegistered) {
+			httperrorwriter.WriteError(w, appErrors.ErrAlreadyRegistered, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	refreshToken, err := h.srv.CreateRefreshToken(r.Context(), authData.Login, time.Now().Add(h.refreshTokenTTL))
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	h.writeTokens(w, r, authData.Login, refreshToken, http.StatusCreated, logErrPrefix)
+}
+
+// @Tags Auth
+// @Summary Запрос получения токена для авторизации
+// @Description Запрос для получения короткоживущего JWT и токена обновления для /token/refresh в Cookie и теле ответа. После каждой неудачной попытки следующая с тем же логином или IP-адресом возможна лишь через время, которое удваивается с каждой попыткой (начиная с FAILED_LOGIN_BACKOFF), а после MAX_FAILED_LOGINS попыток с логином или MAX_FAILED_LOGINS_PER_IP попыток с IP-адреса вход блокируется на LOCKOUT_DURATION, до тех пор сервис отвечает 429 с заголовком Retry-After. Неизвестный логин и неверный пароль неразличимы
+// @Accept json
+// @Produce json
+// @Param input body domain.AuthorizationData true "аутентификационные данные"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 429
+// @Failure 500
+// @Router /login [post]
+func (h *authorization) LogIn(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.LogIn():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var authData domain.AuthorizationData
+	if err = d.Decode(&authData); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.CheckAuth(r.Context(), authData.Login, authData.Password, clientIP(r))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrInvalidCredentials) {
+			httperrorwriter.WriteError(w, appErrors.ErrInvalidCredentials, http.StatusUnauthorized, logErrPrefix)
+			return
+		}
+
+		var retryErr *appErrors.RetryAfterError
+		if errors.As(err, &retryErr) {
+			writeRetryAfter(w, retryErr, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrUserIsDisabled) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserIsDisabled, http.StatusForbidden, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	refreshToken, err := h.srv.CreateRefreshToken(r.Context(), authData.Login, time.Now().Add(h.refreshTokenTTL))
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	h.writeTokens(w, r, authData.Login, refreshToken, http.StatusOK, logErrPrefix)
+}
+
+// @Tags Auth
+// @Summary Запрос обновления токенов
+// @Description Запрос для получения новой пары JWT и токена обновления в обмен на токен обновления (из тела запроса или Cookie refreshToken), каждый токен обновления можно использовать только один раз, повторное использование отзывает все токены обновления пользователя
+// @Accept json
+// @Produce json
+// @Param input body domain.RefreshToken false "токен обновления, если его нет, он берется из Cookie"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /token/refresh [post]
+func (h *authorization) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.RefreshToken():"
+
+	refreshToken, ok := readRefreshToken(w, r, false, logErrPrefix)
+	if !ok {
+		return
+	}
+
+	login, newRefreshToken, err := h.srv.RotateRefreshToken(r.Context(), refreshToken, time.Now().Add(h.refreshTokenTTL))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrRefreshTokenIsInvalid) {
+			httperrorwriter.WriteError(w, appErrors.ErrRefreshTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	h.writeTokens(w, r, login, newRefreshToken, http.StatusOK, logErrPrefix)
+}
+
+// @Tags Auth
+// @Summary Запрос выхода из filmoteka
+// @Description Запрос для выхода из сервиса: JWT из заголовка Authorization или Cookie, если он передан, перестает приниматься, токен обновления (из тела запроса или Cookie refreshToken) отзывается, Cookie очищаются. Токен обновления можно не передавать, если передан действующий JWT
+// @Accept json
+// @Param input body domain.RefreshToken false "токен обновления, если его нет, он берется из Cookie"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /logout [post]
+func (h *authorization) LogOut(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.LogOut():"
+
+	// the access token is revoked first if it is still valid, so it stops being accepted even without a refresh token
+	var accessTokenRevoked bool
+	authToken, err := jwt.FromRequest(r)
+	if err == nil {
+		claims, err := jwt.ParseJWT(authToken, h.JWTKey)
+		if err == nil {
+			err = h.srv.RevokeToken(r.Context(), claims.ID, claims.ExpiresAt.Time)
+			if err != nil {
+				logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+				httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+				return
+			}
+
+			accessTokenRevoked = true
+		}
+	}
+
+	refreshToken, ok := readRefreshToken(w, r, accessTokenRevoked, logErrPrefix)
+	if !ok {
+		return
+	}
+
+	if refreshToken != "" {
+		err = h.srv.RevokeRefreshToken(r.Context(), refreshToken)
+		if err != nil {
+			logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+			httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+			return
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: "authToken", Path: "/", MaxAge: -1})
+	http.SetCookie(w, &http.Cookie{Name: "refreshToken", Path: "/", MaxAge: -1, HttpOnly: true})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Auth
+// @Summary Запрос смены пароля
+// @Description Запрос для смены пароля по текущему паролю, новый пароль должен соответствовать той же политике, что и при регистрации, неудачные попытки ограничиваются так же, как для /login, все выданные до этого токены перестают приниматься, а в ответе, как и при /login, выдается новая пара токенов
+// @Accept json
+// @Produce json
+// @Param input body domain.PasswordChange true "аутентификационные данные и новый пароль"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 429
+// @Failure 500
+// @Router /password [post]
+func (h *authorization) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ChangePassword():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var passwordChange domain.PasswordChange
+	if err = d.Decode(&passwordChange); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if passwordChange.NewPassword == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoNewPasswordProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.ChangePassword(r.Context(), passwordChange.Login, passwordChange.Password, passwordChange.NewPassword, clientIP(r))
+	if err != nil {
+		var policyErr *appErrors.PolicyError
+		if errors.As(err, &policyErr) {
+			httperrorwriter.WriteError(w, policyErr, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrInvalidCredentials) {
+			httperrorwriter.WriteError(w, appErrors.ErrInvalidCredentials, http.StatusUnauthorized, logErrPrefix)
+			return
+		}
+
+		var retryErr *appErrors.RetryAfterError
+		if errors.As(err, &retryErr) {
+			writeRetryAfter(w, retryErr, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrUserIsDisabled) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserIsDisabled, http.StatusForbidden, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	refreshToken, err := h.srv.CreateRefreshToken(r.Context(), passwordChange.Login, time.Now().Add(h.refreshTokenTTL))
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	h.writeTokens(w, r, passwordChange.Login, refreshToken, http.StatusOK, logErrPrefix)
+}
+
+// @Tags Auth
+// @Summary Запрос сброса пароля
+// @Description Запрос для установки нового пароля по одноразовому токену сброса, выданному администратором, новый пароль должен соответствовать той же политике, что и при регистрации, все выданные до этого токены перестают приниматься, после сброса нужно войти через /login
+// @Accept json
+// @Param input body domain.PasswordReset true "токен сброса пароля и новый пароль"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /password/reset [post]
+func (h *authorization) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ResetPassword():"
+
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var passwordReset domain.PasswordReset
+	if err = d.Decode(&passwordReset); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if passwordReset.ResetToken == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrResetTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+		return
+	}
+
+	if passwordReset.NewPassword == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoNewPasswordProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.ResetPassword(r.Context(), passwordReset.ResetToken, passwordReset.NewPassword)
+	if err != nil {
+		var policyErr *appErrors.PolicyError
+		if errors.As(err, &policyErr) {
+			httperrorwriter.WriteError(w, policyErr, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrResetTokenIsInvalid) {
+			httperrorwriter.WriteError(w, appErrors.ErrResetTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *authorization) writeTokens(w http.ResponseWriter, r *http.Request, login string, refreshToken string, statusCode int, logErrPrefix string) {
+	role, err := h.srv.GetRole(r.Context(), login)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusInternalServerError, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	tokenStr, err := jwt.CreateJWT(login, role.Name, role.Permissions, []byte(h.JWTKey), time.Now().Add(h.accessTokenTTL))
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   "authToken",
+		Value:  tokenStr,
+		Path:   "/",
+		MaxAge: int(h.accessTokenTTL.Seconds()),
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refreshToken",
+		Value:    refreshToken,
+		Path:     "/",
+		MaxAge:   int(h.refreshTokenTTL.Seconds()),
+		HttpOnly: true,
+	})
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	e := json.NewEncoder(w)
+	err = e.Encode(domain.Token{Token: tokenStr, RefreshToken: refreshToken})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+	}
+}
+
+func clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return ip
+}
+
+func writeRetryAfter(w http.ResponseWriter, err *appErrors.RetryAfterError, logErrPrefix string) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds()))))
+	httperrorwriter.WriteError(w, err, http.StatusTooManyRequests, logErrPrefix)
+}
+
+func readRefreshToken(w http.ResponseWriter, r *http.Request, optional bool, logErrPrefix string) (string, bool) {
+	if r.ContentLength != 0 {
+		err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+		if err != nil {
+			return "", false
+		}
+
+		d := json.NewDecoder(r.Body)
+		d.DisallowUnknownFields()
+
+		var refreshToken domain.RefreshToken
+		if err = d.Decode(&refreshToken); err != nil {
+			httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+			return "", false
+		}
+
+		if refreshToken.RefreshToken != "" {
+			return refreshToken.RefreshToken, true
+		}
 	}
 
-	if pageStr == "" {
-		pageStr = "1"
+	cookie, err := r.Cookie("refreshToken")
+	if (err != nil || cookie.Value == "") && optional {
+		return "", true
 	}
 
-	if limitStr == "" {
-		limitStr = "15"
+	if err != nil || cookie.Value == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoRefreshTokenProvided, http.StatusUnauthorized, logErrPrefix)
+		return "", false
 	}
 
-	if field != "title" && field != "rating" && field != "release_date" {
-		httperrorwriter.WriteError(w, appErrors.ErrUnknownSortField, http.StatusBadRequest, logErrPrefix)
-		return
-	}
+	return cookie.Value, true
+}
 
-	if order != "desc" && order != "asc" {
-		httperrorwriter.WriteError(w, appErrors.ErrUnknownOrder, http.StatusBadRequest, logErrPrefix)
-		return
-	}
+// @Tags Users
+// @Summary Запрос получения списка пользователей
+// @Description Запрос для получения списка пользователей, отсортированного по логину, с их ролями и временем отключения учетной записи
+// @Produce json
+// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
+// @Param after query string false "курсор следующей страницы (nextCursor из предыдущего ответа), пустое значение - первая страница; при его указании ответ содержит список и nextCursor, page не используется"
+// @Param limit query int false "максимальное число записей на странице, в диапазоне [1, 100] (по умолчанию 15)" Example(1)
+// @Success 200
+// @Header 200,204 {integer} X-Total-Count "общее число найденных записей"
+// @Header 200,204 {string} Link "ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /users [get]
+func (h *authorization) ReadUsers(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadUsers():"
 
-	page, err := strconv.Atoi(pageStr)
+	pagination, err := parsePagination(r, 15)
 	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrPageInNotANumber, http.StatusBadRequest, logErrPrefix)
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
+	users, pageInfo, err := h.srv.ReadUsers(r.Context(), pagination)
 	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		if errors.Is(err, appErrors.ErrWrongCursor) {
+			httperrorwriter.WriteError(w, appErrors.ErrWrongCursor, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
-	if page < 1 {
-		httperrorwriter.WriteError(w, appErrors.ErrPageNumberIsTooSmall, http.StatusBadRequest, logErrPrefix)
+	writePaginationHeaders(w, r, pagination, pageInfo)
+
+	if len(users) == 0 {
+		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
-	if limit < 1 || limit > 100 {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitParameterNotInCorrectRange, http.StatusBadRequest, logErrPrefix)
-		return
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	e := json.NewEncoder(w)
+	if r.URL.Query().Has("after") {
+		err = e.Encode(domain.UsersPage{Users: users, NextCursor: pageInfo.NextCursor})
+	} else {
+		err = e.Encode(users)
 	}
 
-	films, err := h.srv.ReadFilms(r.Context(), field, order, page, limit)
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
-		return
 	}
+}
 
-	if len(films) == 0 {
-		w.WriteHeader(http.StatusNoContent)
+// @Tags Users
+// @Summary Запрос получения списка ролей
+// @Description Запрос для получения списка ролей с их разрешениями, разрешения ролей задаются в БД в таблице role_permissions
+// @Produce json
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /roles [get]
+func (h *authorization) ReadRoles(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadRoles():"
+
+	roles, err := h.srv.ReadRoles(r.Context())
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
@@ -644,77 +3215,181 @@ func (h *film) ReadFilms(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 
 	e := json.NewEncoder(w)
-	err = e.Encode(films)
+	err = e.Encode(roles)
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 	}
 }
 
-// @Tags Films
-// @Summary Запрос поиска фильмов в БД
-// @Description Запрос для поиска фильмов в БД по фрагменту названия фильма и/или имени актера, по умолчанию выдает 1 самый подходящий фильм, для успешного запроса надо указать хотя бы один из фрагментов
-// @Produce json
-// @Param title query string false "фрагмент названия фильма для поиска" Example(film)
-// @Param order query string false "фрагмент имени актера для поиска" Example(Val)
-// @Param page query int false "номер страницы, начинается с 1 (по умолчанию 1)" Example(1)
-// @Param limit query int false "максимальное число актеров на странице, в диапазоне [1, 100] (по умолчанию 1)" Example(1)
-// @Success 200
+// @Tags Users
+// @Summary Запрос назначения роли пользователю
+// @Description Запрос для назначения пользователю роли (viewer, editor, admin или другой роли из БД), все токены пользователя при этом отзываются, и новые разрешения он получит, заново войдя через /login. Изменить собственную роль нельзя
+// @Accept json
+// @Param login path string true "логин пользователя" Example(login)
+// @Param input body domain.UserRole true "роль"
 // @Success 204
 // @Failure 400
 // @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
 // @Failure 500
-// @Router /films/search [get]
-func (h *film) FindFilms(w http.ResponseWriter, r *http.Request) {
+// @Router /user/{login}/role [put]
+func (h *authorization) SetRole(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.FindFilms():"
+	const logErrPrefix = "handlers.SetRole():"
 
-	filmTitleFragment := r.URL.Query().Get("title")
-	actorNameFragment := r.URL.Query().Get("name")
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-	if pageStr == "" {
-		pageStr = "1"
+	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
+	if err != nil {
+		return
 	}
 
-	if limitStr == "" {
-		limitStr = "1"
-	}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrPageInNotANumber, http.StatusBadRequest, logErrPrefix)
+	var userRole domain.UserRole
+	if err = d.Decode(&userRole); err != nil {
+		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitIsNotANumber, http.StatusBadRequest, logErrPrefix)
+	if userRole.Role == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoRoleProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	if page < 1 {
-		httperrorwriter.WriteError(w, appErrors.ErrPageNumberIsTooSmall, http.StatusBadRequest, logErrPrefix)
+	h.changeUser(w, r, false, logErrPrefix, func(ctx context.Context, login string) error {
+		return h.srv.SetRole(ctx, login, userRole.Role)
+	})
+}
+
+// @Tags Users
+// @Summary Запрос выдачи токена сброса пароля
+// @Description Запрос для выдачи одноразового токена, по которому пользователь может задать новый пароль через /password/reset, токен действует ограниченное время, выдача нового токена отменяет предыдущий
+// @Produce json
+// @Param login path string true "логин пользователя" Example(login)
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /user/{login}/password/reset [post]
+func (h *authorization) CreatePasswordResetToken(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CreatePasswordResetToken():"
+
+	login := r.PathValue("login")
+	if login == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoLoginProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	if limit < 1 || limit > 100 {
-		httperrorwriter.WriteError(w, appErrors.ErrLimitParameterNotInCorrectRange, http.StatusBadRequest, logErrPrefix)
+	expiresAt := time.Now().Add(h.passwordResetTokenTTL)
+	resetToken, err := h.srv.CreatePasswordResetToken(r.Context(), login, expiresAt)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
-	if filmTitleFragment == "" && actorNameFragment == "" {
-		httperrorwriter.WriteError(w, appErrors.ErrNoFragmentsProvided, http.StatusBadRequest, logErrPrefix)
-		return
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	e := json.NewEncoder(w)
+	err = e.Encode(domain.PasswordResetToken{ResetToken: resetToken, ExpiresAt: expiresAt.UTC().Truncate(time.Second)})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 	}
+}
+
+// @Tags Users
+// @Summary Запрос отключения учетной записи
+// @Description Запрос для отключения учетной записи: пользователь не сможет войти, его токены обновления отзываются, а JWT перестают приниматься. Отключить самого себя нельзя
+// @Param login path string true "логин пользователя" Example(login)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /user/{login}/disable [post]
+func (h *authorization) DisableUser(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DisableUser():"
+
+	h.changeUser(w, r, false, logErrPrefix, func(ctx context.Context, login string) error {
+		return h.srv.SetDisabled(ctx, login, true)
+	})
+}
+
+// @Tags Users
+// @Summary Запрос включения учетной записи
+// @Description Запрос для включения отключенной учетной записи, после него пользователю нужно заново войти через /login
+// @Param login path string true "логин пользователя" Example(login)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /user/{login}/enable [post]
+func (h *authorization) EnableUser(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.EnableUser():"
+
+	h.changeUser(w, r, true, logErrPrefix, func(ctx context.Context, login string) error {
+		return h.srv.SetDisabled(ctx, login, false)
+	})
+}
+
+// @Tags Users
+// @Summary Запрос удаления пользователя
+// @Description Запрос для удаления учетной записи вместе с ее токенами обновления, записи журнала изменений, сделанные пользователем, сохраняются. Удалить самого себя нельзя
+// @Param login path string true "логин пользователя" Example(login)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /user/{login} [delete]
+func (h *authorization) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteUser():"
+
+	h.changeUser(w, r, false, logErrPrefix, h.srv.DeleteUser)
+}
+
+// @Tags Users
+// @Summary Запрос получения списка блокировок входа
+// @Description Запрос для получения списка логинов и IP-адресов, вход с которыми временно запрещен из-за неудачных попыток (MAX_FAILED_LOGINS и MAX_FAILED_LOGINS_PER_IP попыток подряд блокируют вход на LOCKOUT_DURATION), начиная с последних
+// @Produce json
+// @Success 200
+// @Success 204
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /lockouts [get]
+func (h *authorization) ReadLockouts(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadLockouts():"
 
-	films, err := h.srv.FindFilms(r.Context(), filmTitleFragment, actorNameFragment, page, limit)
+	lockouts, err := h.srv.ReadLockouts(r.Context())
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
-	if len(films) == 0 {
+	if len(lockouts) == 0 {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
@@ -723,35 +3398,45 @@ func (h *film) FindFilms(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 
 	e := json.NewEncoder(w)
-	err = e.Encode(films)
+	err = e.Encode(lockouts)
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 	}
 }
 
-type authorization struct {
-	srv    domain.AuthorizationService
-	JWTKey string
-}
+// @Tags Users
+// @Summary Запрос снятия блокировки входа
+// @Description Запрос для снятия блокировки входа с логина и сброса счетчика его неудачных попыток, блокировки IP-адресов при этом сохраняются
+// @Param login path string true "логин пользователя" Example(login)
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /user/{login}/unlock [post]
+func (h *authorization) Unlock(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.Unlock():"
 
-func NewAuthorization(srv domain.AuthorizationService, jwtKey string) *authorization {
-	return &authorization{srv: srv, JWTKey: jwtKey}
+	h.changeUser(w, r, true, logErrPrefix, h.srv.Unlock)
 }
 
-// @Tags Auth
-// @Summary Запрос регистрации в filmoteka
-// @Description Запрос для регистрации в сервисе, производится регистрация обычного пользователя (если нужен админ, надо задать соответствующее поле в БД в таблице auth и заново получить токен через login) и выдается JWT (можно указать в заголовке Authorization) на 24 часа (также записывается в Cookie)
+// @Tags Users
+// @Summary Запрос создания API-ключа
+// @Description Запрос для создания именованного API-ключа для пакетных задач, ключ передается в заголовке X-API-Key вместо JWT. Ключ с областью read может только читать фильмы, актеров и жанры, с областью write - также изменять их. Сам ключ выводится только в ответе на этот запрос, в журнале изменений действия по ключу записываются от имени api-key:<id>
 // @Accept json
 // @Produce json
-// @Param input body domain.AuthorizationData true "аутентификационные данные"
+// @Param input body domain.NewAPIKey true "название и область доступа"
 // @Success 201
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 409
 // @Failure 500
-// @Router /register [post]
-func (h *authorization) Register(w http.ResponseWriter, r *http.Request) {
+// @Router /apikey [post]
+func (h *authorization) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.Register():"
+	const logErrPrefix = "handlers.CreateAPIKey():"
 
 	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
 	if err != nil {
@@ -761,16 +3446,26 @@ func (h *authorization) Register(w http.ResponseWriter, r *http.Request) {
 	d := json.NewDecoder(r.Body)
 	d.DisallowUnknownFields()
 
-	var authData domain.AuthorizationData
-	if err = d.Decode(&authData); err != nil {
+	var newAPIKey domain.NewAPIKey
+	if err = d.Decode(&newAPIKey); err != nil {
 		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	err = h.srv.Register(r.Context(), authData.Login, authData.Password)
+	if newAPIKey.Name == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoNameProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	apiKey, err := h.srv.CreateAPIKey(r.Context(), newAPIKey.Name, newAPIKey.Scope)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrAlreadyRegistered) {
-			httperrorwriter.WriteError(w, appErrors.ErrAlreadyRegistered, http.StatusConflict, logErrPrefix)
+		if errors.Is(err, appErrors.ErrUnknownAPIKeyScope) {
+			httperrorwriter.WriteError(w, appErrors.ErrUnknownAPIKeyScope, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrAPIKeyAlreadyExists) {
+			httperrorwriter.WriteError(w, appErrors.ErrAPIKeyAlreadyExists, http.StatusConflict, logErrPrefix)
 			return
 		}
 
@@ -779,93 +3474,84 @@ func (h *authorization) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isAdmin, err := h.srv.IsAdmin(r.Context(), authData.Login)
-	if err != nil {
-		if errors.Is(err, appErrors.ErrUserNotFound) {
-			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusInternalServerError, logErrPrefix)
-			return
-		}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
 
+	e := json.NewEncoder(w)
+	err = e.Encode(apiKey)
+	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
-		return
 	}
+}
+
+// @Tags Users
+// @Summary Запрос получения списка API-ключей
+// @Description Запрос для получения списка API-ключей, включая отозванные, начиная с последних созданных, сами ключи не выводятся
+// @Produce json
+// @Success 200
+// @Success 204
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /apikeys [get]
+func (h *authorization) ReadAPIKeys(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ReadAPIKeys():"
 
-	tokenStr, err := jwt.CreateJWT(isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
+	apiKeys, err := h.srv.ReadAPIKeys(r.Context())
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
 		return
 	}
 
-	cookie := http.Cookie{
-		Name:   "authToken",
-		Value:  tokenStr,
-		MaxAge: 86400,
+	if len(apiKeys) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
 	}
 
-	http.SetCookie(w, &cookie)
-
 	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusOK)
 
 	e := json.NewEncoder(w)
-	err = e.Encode(domain.Token{Token: tokenStr})
+	err = e.Encode(apiKeys)
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
 	}
 }
 
-// @Tags Auth
-// @Summary Запрос получения токена для авторизации
-// @Description Запрос для получения JWT в Cookie и теле ответа
-// @Accept json
-// @Produce json
-// @Param input body domain.AuthorizationData true "аутентификационные данные"
-// @Success 200
+// @Tags Users
+// @Summary Запрос отзыва API-ключа
+// @Description Запрос для отзыва API-ключа, после него ключ перестает приниматься, а его название можно использовать для нового ключа
+// @Param id path int true "id API-ключа" Example(1)
+// @Success 204
 // @Failure 400
 // @Failure 401
+// @Failure 403
+// @Failure 404
 // @Failure 500
-// @Router /login [post]
-func (h *authorization) LogIn(w http.ResponseWriter, r *http.Request) {
+// @Router /apikey/{id} [delete]
+func (h *authorization) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	const logErrPrefix = "handlers.LogIn():"
-
-	err := jsonhttpvalidator.ValidateJSONRequest(w, r, logErrPrefix)
-	if err != nil {
-		return
-	}
+	const logErrPrefix = "handlers.RevokeAPIKey():"
 
-	d := json.NewDecoder(r.Body)
-	d.DisallowUnknownFields()
+	idStr := r.PathValue("id")
 
-	var authData domain.AuthorizationData
-	if err = d.Decode(&authData); err != nil {
-		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
+	if idStr == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoIDProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	err = h.srv.CheckAuth(r.Context(), authData.Login, authData.Password)
+	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrUserNotFound) {
-			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusUnauthorized, logErrPrefix)
-			return
-		}
-
-		if errors.Is(err, appErrors.ErrWrongPassword) {
-			httperrorwriter.WriteError(w, appErrors.ErrWrongPassword, http.StatusUnauthorized, logErrPrefix)
-			return
-		}
-
-		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		httperrorwriter.WriteError(w, appErrors.ErrIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	isAdmin, err := h.srv.IsAdmin(r.Context(), authData.Login)
+	err = h.srv.RevokeAPIKey(r.Context(), id)
 	if err != nil {
-		if errors.Is(err, appErrors.ErrUserNotFound) {
-			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusInternalServerError, logErrPrefix)
+		if errors.Is(err, appErrors.ErrAPIKeyNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrAPIKeyNotFound, http.StatusNotFound, logErrPrefix)
 			return
 		}
 
@@ -874,28 +3560,38 @@ func (h *authorization) LogIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Logger().Infoln(isAdmin)
-	tokenStr, err := jwt.CreateJWT(isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
-	if err != nil {
-		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
-		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Administrators can not lock themselves out, unless ownAccountAllowed is true the change is not applied to their own account.
+func (h *authorization) changeUser(w http.ResponseWriter, r *http.Request, ownAccountAllowed bool, logErrPrefix string, change func(ctx context.Context, login string) error) {
+	login := r.PathValue("login")
+	if login == "" {
+		httperrorwriter.WriteError(w, appErrors.ErrNoLoginProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
-	cookie := http.Cookie{
-		Name:   "authToken",
-		Value:  tokenStr,
-		MaxAge: 86400,
+	if !ownAccountAllowed && login == domain.LoginFromContext(r.Context()) {
+		httperrorwriter.WriteError(w, appErrors.ErrOwnAccountChange, http.StatusConflict, logErrPrefix)
+		return
 	}
 
-	http.SetCookie(w, &cookie)
+	err := change(r.Context(), login)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+		if errors.Is(err, appErrors.ErrRoleDoesNotExist) {
+			httperrorwriter.WriteError(w, appErrors.ErrRoleDoesNotExist, http.StatusNotFound, logErrPrefix)
+			return
+		}
 
-	e := json.NewEncoder(w)
-	err = e.Encode(domain.Token{Token: tokenStr})
-	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
 	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
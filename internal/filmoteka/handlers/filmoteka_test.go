@@ -5,8 +5,10 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -16,8 +18,26 @@ import (
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain/mocks"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/service"
+	"github.com/PoorMercymain/filmoteka/pkg/jwt"
 )
 
+var testCredentialsPolicy = domain.CredentialsPolicy{
+	LoginCharacters:          regexp.MustCompile("^[a-zA-Z0-9._-]*$"),
+	LoginMinLength:           3,
+	LoginMaxLength:           32,
+	PasswordMinLength:        8,
+	PasswordRequireUppercase: true,
+	PasswordRequireLowercase: true,
+	PasswordRequireDigit:     true,
+}
+
+var testLoginThrottlePolicy = domain.LoginThrottlePolicy{
+	MaxFailures:      5,
+	MaxFailuresPerIP: 20,
+	Backoff:          time.Second,
+	LockoutDuration:  15 * time.Minute,
+}
+
 func testRouter(t *testing.T) *http.ServeMux {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -26,83 +46,290 @@ func testRouter(t *testing.T) *http.ServeMux {
 
 	ar := mocks.NewMockActorRepository(ctrl)
 	as := service.NewActor(ar)
-	ah := NewActor(as)
+	ah := NewActor(as, 0.3)
 
 	fr := mocks.NewMockFilmRepository(ctrl)
 	fs := service.NewFilm(fr)
-	fh := NewFilm(fs)
+	fh := NewFilm(fs, 0.3)
+
+	gr := mocks.NewMockGenreRepository(ctrl)
+	gs := service.NewGenre(gr)
+	gh := NewGenre(gs)
 
 	aur := mocks.NewMockAuthorizationRepository(ctrl)
-	aus := service.NewAuthorization(aur)
-	auh := NewAuthorization(aus, "")
+	aus := service.NewAuthorization(aur, testCredentialsPolicy, testLoginThrottlePolicy)
+	auh := NewAuthorization(aus, "", time.Minute, time.Hour, time.Hour)
 
 	hash, err := bcrypt.GenerateFromPassword([]byte("abc"), bcrypt.DefaultCost)
 	require.NoError(t, err)
 
 	ar.EXPECT().CreateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("")).MaxTimes(1)
 	ar.EXPECT().CreateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil).MaxTimes(1)
-	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
-	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
-	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
-	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
-	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
-	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
-	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
-	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 0), nil).MaxTimes(1)
-	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), nil).MaxTimes(1)
-	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
-	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, appErrors.ErrActorDoesNotExist).MaxTimes(1)
-	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("")).MaxTimes(1)
-	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil).MaxTimes(2)
-	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
-	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorDoesNotExist).MaxTimes(1)
-	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
-	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
-	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
-	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
-	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
-	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
-	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
-	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 0), nil).MaxTimes(1)
-	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), nil).MaxTimes(1)
-	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
-	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 0), nil).MaxTimes(1)
-	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), nil).MaxTimes(1)
+	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().UpdateActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	ar.EXPECT().PatchActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	ar.EXPECT().PatchActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().PatchActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().PatchActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrRevisionNotFound).MaxTimes(1)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().RevertActor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().DeleteActor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	ar.EXPECT().RestoreActor(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().RestoreActor(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().RestoreActor(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	ar.EXPECT().PurgeActor(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().PurgeActor(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	ar.EXPECT().PurgeActor(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	ar.EXPECT().ReadDeletedActors(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	ar.EXPECT().ReadDeletedActors(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	ar.EXPECT().ReadDeletedActors(gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	ar.EXPECT().ReadDeletedActors(gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), domain.PageInfo{Total: 1}, nil).MaxTimes(1)
+	ar.EXPECT().ReadDeletedActors(gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActorHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	ar.EXPECT().ReadActorHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	ar.EXPECT().ReadActorHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActorHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 1), domain.PageInfo{Total: 1}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActorHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActor(gomock.Any(), gomock.Any()).Return(domain.OutputActor{}, appErrors.ErrNotFoundInDB).MaxTimes(1)
+	ar.EXPECT().ReadActor(gomock.Any(), gomock.Any()).Return(domain.OutputActor{}, errors.New("")).MaxTimes(1)
+	ar.EXPECT().ReadActor(gomock.Any(), gomock.Any()).Return(domain.OutputActor{}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), domain.PageInfo{}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	ar.EXPECT().ReadActors(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+
+	ar.EXPECT().FindActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	ar.EXPECT().FindActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	ar.EXPECT().FindActors(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputActor, 1), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
+	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, appErrors.ErrActorDoesNotExist).MaxTimes(1)
+	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("")).MaxTimes(1)
+	fr.EXPECT().CreateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil).MaxTimes(2)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorDoesNotExist).MaxTimes(1)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().UpdateFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorDoesNotExist).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().PatchFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrRevisionNotFound).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorDoesNotExist).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrPersonDoesNotExist).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrGenreDoesNotExist).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrActorNotBornBeforeFilmRelease).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().RevertFilm(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().DeleteFilm(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().RestoreFilm(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().RestoreFilm(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().RestoreFilm(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().PurgeFilm(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().PurgeFilm(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().PurgeFilm(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().ReadDeletedFilms(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	fr.EXPECT().ReadDeletedFilms(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	fr.EXPECT().ReadDeletedFilms(gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().ReadDeletedFilms(gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), domain.PageInfo{Total: 1}, nil).MaxTimes(1)
+	fr.EXPECT().ReadDeletedFilms(gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilmHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	fr.EXPECT().ReadFilmHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	fr.EXPECT().ReadFilmHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilmHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 1), domain.PageInfo{Total: 1}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilmHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.AuditRecord, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilm(gomock.Any(), gomock.Any()).Return(domain.OutputFilm{}, appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().ReadFilm(gomock.Any(), gomock.Any()).Return(domain.OutputFilm{}, errors.New("")).MaxTimes(1)
+	fr.EXPECT().ReadFilm(gomock.Any(), gomock.Any()).Return(domain.OutputFilm{}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), domain.PageInfo{Total: 2, NextCursor: "abc"}, nil).MaxTimes(1)
+	fr.EXPECT().ReadFilms(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().FindFilms(gomock.Any(), gomock.Any(), gomock.Any()).Return(make([]domain.OutputFilm, 1), domain.PageInfo{}, nil).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrPersonDoesNotExist).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrCrewCreditAlreadyExists).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().AddCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	fr.EXPECT().DeleteCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrVersionMismatch).MaxTimes(1)
+	fr.EXPECT().DeleteCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	fr.EXPECT().DeleteCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	fr.EXPECT().DeleteCrewCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	gr.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(0, appErrors.ErrGenreAlreadyExists).MaxTimes(1)
+	gr.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(0, errors.New("")).MaxTimes(1)
+	gr.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(1, nil).MaxTimes(1)
+	gr.EXPECT().UpdateGenre(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	gr.EXPECT().UpdateGenre(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrGenreAlreadyExists).MaxTimes(1)
+	gr.EXPECT().UpdateGenre(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	gr.EXPECT().UpdateGenre(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	gr.EXPECT().DeleteGenre(gomock.Any(), gomock.Any()).Return(appErrors.ErrNotFoundInDB).MaxTimes(1)
+	gr.EXPECT().DeleteGenre(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	gr.EXPECT().DeleteGenre(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	gr.EXPECT().ReadGenres(gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
+	gr.EXPECT().ReadGenres(gomock.Any()).Return(make([]domain.OutputGenre, 0), nil).MaxTimes(1)
+	gr.EXPECT().ReadGenres(gomock.Any()).Return(make([]domain.OutputGenre, 1), nil).MaxTimes(1)
 	aur.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrAlreadyRegistered).MaxTimes(1)
 	aur.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
-	aur.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, appErrors.ErrUserNotFound).MaxTimes(1)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, errors.New("")).MaxTimes(1)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, nil).MaxTimes(1)
+	aur.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(4)
+	aur.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{}, appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{Name: "viewer", Permissions: []string{domain.PermissionFilmRead}}, nil).MaxTimes(1)
 	aur.EXPECT().GetPasswordHash(gomock.Any(), gomock.Any()).Return("", appErrors.ErrUserNotFound).MaxTimes(1)
 	aur.EXPECT().GetPasswordHash(gomock.Any(), gomock.Any()).Return("", nil).MaxTimes(1)
 	aur.EXPECT().GetPasswordHash(gomock.Any(), gomock.Any()).Return("", errors.New("")).MaxTimes(1)
-	aur.EXPECT().GetPasswordHash(gomock.Any(), gomock.Any()).Return(string(hash), nil).MaxTimes(3)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, appErrors.ErrUserNotFound).MaxTimes(1)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, errors.New("")).MaxTimes(1)
-	aur.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(false, nil).MaxTimes(1)
+	aur.EXPECT().GetPasswordHash(gomock.Any(), gomock.Any()).Return(string(hash), nil).MaxTimes(8)
+	aur.EXPECT().ReserveLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Duration(0), errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReserveLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Minute, nil).MaxTimes(1)
+	aur.EXPECT().ReserveLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).AnyTimes()
+	aur.EXPECT().ReleaseLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReleaseLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().ResetLoginFailures(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().ResetLoginFailures(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().ReadLockouts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReadLockouts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.LoginFailures{}, nil).MaxTimes(1)
+	aur.EXPECT().ReadLockouts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.LoginFailures{{Kind: domain.LockoutKindLogin, Subject: "abc", Failures: 5, LastFailureAt: time.Now()}}, nil).MaxTimes(1)
+
+	aur.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.APIKey{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.APIKey{}, appErrors.ErrAPIKeyAlreadyExists).MaxTimes(1)
+	aur.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.APIKey{ID: 1, Name: "import", Scope: domain.APIKeyScopeRead, CreatedBy: "admin", CreatedAt: time.Now()}, nil).MaxTimes(1)
+
+	aur.EXPECT().ReadAPIKeys(gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReadAPIKeys(gomock.Any()).Return([]domain.APIKey{}, nil).MaxTimes(1)
+	aur.EXPECT().ReadAPIKeys(gomock.Any()).Return([]domain.APIKey{{ID: 1, Name: "import", Scope: domain.APIKeyScopeRead, CreatedBy: "admin", CreatedAt: time.Now()}}, nil).MaxTimes(1)
+
+	aur.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(appErrors.ErrAPIKeyNotFound).MaxTimes(1)
+	aur.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{IsDisabled: true}, nil).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{}, nil).AnyTimes()
+	aur.EXPECT().SetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().SetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrResetTokenIsInvalid).MaxTimes(1)
+	aur.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	aur.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{}, appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(domain.Role{Name: "viewer", Permissions: []string{domain.PermissionFilmRead}}, nil).MaxTimes(1)
+	aur.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", appErrors.ErrRefreshTokenIsInvalid).MaxTimes(1)
+	aur.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("")).MaxTimes(1)
+	aur.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("abc", nil).MaxTimes(3)
+	aur.EXPECT().RevokeRefreshToken(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().RevokeRefreshToken(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().RevokeToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().RevokeToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	aur.EXPECT().ReadUsers(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, appErrors.ErrWrongCursor).MaxTimes(1)
+	aur.EXPECT().ReadUsers(gomock.Any(), gomock.Any()).Return(nil, domain.PageInfo{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReadUsers(gomock.Any(), gomock.Any()).Return(make([]domain.User, 0), domain.PageInfo{}, nil).MaxTimes(1)
+	aur.EXPECT().ReadUsers(gomock.Any(), gomock.Any()).Return(make([]domain.User, 1), domain.PageInfo{Total: 1}, nil).MaxTimes(2)
+	aur.EXPECT().ReadRoles(gomock.Any()).Return(nil, errors.New("")).MaxTimes(1)
+	aur.EXPECT().ReadRoles(gomock.Any()).Return(make([]domain.Role, 3), nil).MaxTimes(1)
+	aur.EXPECT().SetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().SetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrRoleDoesNotExist).MaxTimes(1)
+	aur.EXPECT().SetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().SetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	aur.EXPECT().SetDisabled(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().SetDisabled(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().SetDisabled(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+	aur.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(errors.New("")).MaxTimes(1)
+	aur.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
 
 	mux.Handle("POST /actor", http.HandlerFunc(ah.CreateActor))
 	mux.Handle("PUT /actor/{id}", http.HandlerFunc(ah.UpdateActor))
+	mux.Handle("PATCH /actor/{id}", http.HandlerFunc(ah.PatchActor))
 	mux.Handle("DELETE /actor/{id}", http.HandlerFunc(ah.DeleteActor))
+	mux.Handle("POST /actor/{id}/revert/{revision}", http.HandlerFunc(ah.RevertActor))
+	mux.Handle("POST /actor/{id}/restore", http.HandlerFunc(ah.RestoreActor))
+	mux.Handle("DELETE /actor/{id}/purge", http.HandlerFunc(ah.PurgeActor))
+	mux.Handle("GET /actors/deleted", http.HandlerFunc(ah.ReadDeletedActors))
+	mux.Handle("GET /actor/{id}/history", http.HandlerFunc(ah.ReadActorHistory))
+	mux.Handle("GET /actor/{id}", http.HandlerFunc(ah.ReadActor))
 	mux.Handle("GET /actors", http.HandlerFunc(ah.ReadActors))
+	mux.Handle("GET /actors/search", http.HandlerFunc(ah.FindActors))
 
 	mux.Handle("POST /film", http.HandlerFunc(fh.CreateFilm))
 	mux.Handle("PUT /film/{id}", http.HandlerFunc(fh.UpdateFilm))
+	mux.Handle("PATCH /film/{id}", http.HandlerFunc(fh.PatchFilm))
 	mux.Handle("DELETE /film/{id}", http.HandlerFunc(fh.DeleteFilm))
+	mux.Handle("POST /film/{id}/revert/{revision}", http.HandlerFunc(fh.RevertFilm))
+	mux.Handle("POST /film/{id}/restore", http.HandlerFunc(fh.RestoreFilm))
+	mux.Handle("DELETE /film/{id}/purge", http.HandlerFunc(fh.PurgeFilm))
+	mux.Handle("GET /films/deleted", http.HandlerFunc(fh.ReadDeletedFilms))
+	mux.Handle("GET /film/{id}/history", http.HandlerFunc(fh.ReadFilmHistory))
+	mux.Handle("GET /film/{id}", http.HandlerFunc(fh.ReadFilm))
+	mux.Handle("POST /film/{id}/crew", http.HandlerFunc(fh.AddCrewCredit))
+	mux.Handle("DELETE /film/{id}/crew/{personID}/{job}", http.HandlerFunc(fh.DeleteCrewCredit))
 	mux.Handle("GET /films", http.HandlerFunc(fh.ReadFilms))
 	mux.Handle("GET /films/search", http.HandlerFunc(fh.FindFilms))
 
+	mux.Handle("POST /genre", http.HandlerFunc(gh.CreateGenre))
+	mux.Handle("PUT /genre/{id}", http.HandlerFunc(gh.UpdateGenre))
+	mux.Handle("DELETE /genre/{id}", http.HandlerFunc(gh.DeleteGenre))
+	mux.Handle("GET /genres", http.HandlerFunc(gh.ReadGenres))
+
 	mux.Handle("POST /register", http.HandlerFunc(auh.Register))
 	mux.Handle("POST /login", http.HandlerFunc(auh.LogIn))
+	mux.Handle("POST /token/refresh", http.HandlerFunc(auh.RefreshToken))
+	mux.Handle("POST /logout", http.HandlerFunc(auh.LogOut))
+	mux.Handle("POST /password", http.HandlerFunc(auh.ChangePassword))
+	mux.Handle("GET /lockouts", asAdmin(http.HandlerFunc(auh.ReadLockouts)))
+	mux.Handle("POST /user/{login}/unlock", asAdmin(http.HandlerFunc(auh.Unlock)))
+	mux.Handle("POST /apikey", asAdmin(http.HandlerFunc(auh.CreateAPIKey)))
+	mux.Handle("GET /apikeys", asAdmin(http.HandlerFunc(auh.ReadAPIKeys)))
+	mux.Handle("DELETE /apikey/{id}", asAdmin(http.HandlerFunc(auh.RevokeAPIKey)))
+	mux.Handle("POST /password/reset", http.HandlerFunc(auh.ResetPassword))
+	mux.Handle("POST /user/{login}/password/reset", asAdmin(http.HandlerFunc(auh.CreatePasswordResetToken)))
+	mux.Handle("GET /users", http.HandlerFunc(auh.ReadUsers))
+	mux.Handle("GET /roles", http.HandlerFunc(auh.ReadRoles))
+	mux.Handle("PUT /user/{login}/role", asAdmin(http.HandlerFunc(auh.SetRole)))
+	mux.Handle("POST /user/{login}/disable", asAdmin(http.HandlerFunc(auh.DisableUser)))
+	mux.Handle("POST /user/{login}/enable", asAdmin(http.HandlerFunc(auh.EnableUser)))
+	mux.Handle("DELETE /user/{login}", asAdmin(http.HandlerFunc(auh.DeleteUser)))
 
 	return mux
 }
 
+// asAdmin passes the login of the admin making the request to the handler, as the auth middleware does.
+func asAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(domain.WithLogin(r.Context(), "admin")))
+	})
+}
+
 func request(t *testing.T, ts *httptest.Server, code int, method, content, body, endpoint string) *http.Response {
 	req, err := http.NewRequest(method, ts.URL+endpoint, strings.NewReader(body))
 	require.NoError(t, err)
 	req.Header.Set("Content-Type", content)
+	// changes of films and actors require If-Match, the tests make them regardless of the version
+	req.Header.Set("If-Match", "*")
 
 	resp, err := ts.Client().Do(req)
 	require.NoError(t, err)
@@ -225,6 +452,13 @@ func TestUpdateActor(t *testing.T) {
 		code     int
 		body     string
 	}{
+		{
+			"/actor/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusPreconditionFailed,
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"2000-01-02\"}",
+		},
 		{
 			"/actor/",
 			http.MethodPut,
@@ -267,40 +501,54 @@ func TestUpdateActor(t *testing.T) {
 			http.StatusBadRequest,
 			"{\"name\":\"abc\",\"name\":\"abc\",\"gender\":\"\",\"birthday\":\"\"}",
 		},
+		{
+			"/actor/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"abc\"}",
+		},
 		{
 			"/actor/1",
 			http.MethodPut,
 			"application/json",
 			http.StatusNotFound,
-			"{\"name\":\"abc\", \"gender\":\"\",\"birthday\":\"\"}",
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"2000-01-02\"}",
 		},
 		{
 			"/actor/1",
 			http.MethodPut,
 			"application/json",
 			http.StatusInternalServerError,
-			"{\"name\":\"abc\", \"gender\":\"\",\"birthday\":\"\"}",
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"2000-01-02\"}",
 		},
 		{
 			"/actor/1",
 			http.MethodPut,
 			"application/json",
 			http.StatusNoContent,
-			"{\"name\":\"abc\", \"gender\":\"\",\"birthday\":\"\"}",
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"2000-01-02\"}",
 		},
 		{
 			"/actor/1",
 			http.MethodPut,
 			"application/json",
 			http.StatusNoContent,
-			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"\"}",
+			"{\"name\":\"abc\", \"gender\":\"male\",\"birthday\":\"1990-12-31\"}",
 		},
 		{
 			"/actor/1",
 			http.MethodPut,
 			"application/json",
 			http.StatusNoContent,
-			"{\"name\":\"abc\", \"gender\":\"female\",\"birthday\":\"\"}",
+			"{\"name\":\"abc\", \"gender\":\"female\",\"birthday\":\"1990-12-31\"}",
 		},
 	}
 
@@ -310,7 +558,7 @@ func TestUpdateActor(t *testing.T) {
 	}
 }
 
-func TestDeleteActor(t *testing.T) {
+func TestPatchActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -323,39 +571,102 @@ func TestDeleteActor(t *testing.T) {
 		body     string
 	}{
 		{
-			"/actor/",
-			http.MethodDelete,
-			"",
-			http.StatusNotFound,
-			"",
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusPreconditionFailed,
+			"{\"name\":\"abc\"}",
 		},
 		{
 			"/actor/abc",
-			http.MethodDelete,
-			"",
+			http.MethodPatch,
+			"application/merge-patch+json",
 			http.StatusBadRequest,
-			"",
+			"{\"name\":\"abc\"}",
 		},
 		{
 			"/actor/1",
-			http.MethodDelete,
-			"",
+			http.MethodPatch,
+			"text/plain",
+			http.StatusBadRequest,
+			"{\"name\":\"abc\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"name\":null}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"gender\":null}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"name\":\"\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"gender\":\"abc\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"birthday\":\"abc\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"nickname\":\"abc\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
 			http.StatusNotFound,
-			"",
+			"{\"name\":\"abc\"}",
 		},
 		{
 			"/actor/1",
-			http.MethodDelete,
-			"",
+			http.MethodPatch,
+			"application/merge-patch+json",
 			http.StatusInternalServerError,
-			"",
+			"{\"name\":\"abc\"}",
 		},
 		{
 			"/actor/1",
-			http.MethodDelete,
-			"",
+			http.MethodPatch,
+			"application/merge-patch+json",
 			http.StatusNoContent,
-			"",
+			"{\"gender\":\"female\"}",
+		},
+		{
+			"/actor/1",
+			http.MethodPatch,
+			"application/json",
+			http.StatusNoContent,
+			"{\"birthday\":\"2000-01-02\"}",
 		},
 	}
 
@@ -365,7 +676,7 @@ func TestDeleteActor(t *testing.T) {
 	}
 }
 
-func TestReadActors(t *testing.T) {
+func TestRevertActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -378,59 +689,59 @@ func TestReadActors(t *testing.T) {
 		body     string
 	}{
 		{
-			"/actors?page=a",
-			http.MethodGet,
+			"/actor/abc/revert/1",
+			http.MethodPost,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/actors?limit=a",
-			http.MethodGet,
+			"/actor/1/revert/abc",
+			http.MethodPost,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/actors?page=0",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusBadRequest,
+			http.StatusPreconditionFailed,
 			"",
 		},
 		{
-			"/actors?limit=0",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusBadRequest,
+			http.StatusNotFound,
 			"",
 		},
 		{
-			"/actors?limit=101",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusBadRequest,
+			http.StatusNotFound,
 			"",
 		},
 		{
-			"/actors?page=1&limit=10",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusInternalServerError,
+			http.StatusConflict,
 			"",
 		},
 		{
-			"/actors?page=1&limit=10",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusNoContent,
+			http.StatusInternalServerError,
 			"",
 		},
 		{
-			"/actors?page=1&limit=10",
-			http.MethodGet,
+			"/actor/1/revert/2",
+			http.MethodPost,
 			"",
-			http.StatusOK,
+			http.StatusNoContent,
 			"",
 		},
 	}
@@ -441,7 +752,7 @@ func TestReadActors(t *testing.T) {
 	}
 }
 
-func TestCreateFilm(t *testing.T) {
+func TestDeleteActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -454,123 +765,46 @@ func TestCreateFilm(t *testing.T) {
 		body     string
 	}{
 		{
-			"/film",
-			http.MethodPost,
+			"/actor/1",
+			http.MethodDelete,
+			"",
+			http.StatusPreconditionFailed,
 			"",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"abc\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": \"\",\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [\"3\", \"4\"]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"a\",\"releaseDate\": \"2021-04-13\",\"actorIDs\": [3, 4]}",
 		},
 		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"a\",\"releaseDate\": \"2021-04-13\",\"rating\":12,\"actorIDs\": [3, 4]}",
+			"/actor/",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
 		},
 		{
-			"/film",
-			http.MethodPost,
-			"application/json",
+			"/actor/abc",
+			http.MethodDelete,
+			"",
 			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+			"",
 		},
 		{
-			"/film",
-			http.MethodPost,
-			"application/json",
+			"/actor/1",
+			http.MethodDelete,
+			"",
 			http.StatusNotFound,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+			"",
 		},
 		{
-			"/film",
-			http.MethodPost,
-			"application/json",
+			"/actor/1",
+			http.MethodDelete,
+			"",
 			http.StatusInternalServerError,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusCreated,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+			"",
 		},
 		{
-			"/film",
-			http.MethodPost,
-			"application/json",
-			http.StatusCreated,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7}",
+			"/actor/1",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
 		},
 	}
 
@@ -580,7 +814,7 @@ func TestCreateFilm(t *testing.T) {
 	}
 }
 
-func TestUpdateFilm(t *testing.T) {
+func TestRestoreActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -593,102 +827,32 @@ func TestUpdateFilm(t *testing.T) {
 		body     string
 	}{
 		{
-			"/film/",
-			http.MethodPut,
+			"/actor/abc/restore",
+			http.MethodPost,
 			"",
-			http.StatusNotFound,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/abc",
-			http.MethodPut,
-			"application/json",
 			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
 			"",
-			http.StatusBadRequest,
-			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 12,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusNotFound,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
 		},
 		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
+			"/actor/1/restore",
+			http.MethodPost,
+			"",
 			http.StatusNotFound,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+			"",
 		},
 		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
+			"/actor/1/restore",
+			http.MethodPost,
+			"",
 			http.StatusInternalServerError,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
-		},
-		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actors\": [3, 4]}",
+			"",
 		},
 		{
-			"/film/1",
-			http.MethodPut,
-			"application/json",
+			"/actor/1/restore",
+			http.MethodPost,
+			"",
 			http.StatusNoContent,
-			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+			"",
 		},
 	}
 
@@ -698,7 +862,7 @@ func TestUpdateFilm(t *testing.T) {
 	}
 }
 
-func TestDeleteFilm(t *testing.T) {
+func TestPurgeActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -711,28 +875,28 @@ func TestDeleteFilm(t *testing.T) {
 		body     string
 	}{
 		{
-			"/film/a",
+			"/actor/abc/purge",
 			http.MethodDelete,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/film/1",
+			"/actor/1/purge",
 			http.MethodDelete,
 			"",
 			http.StatusNotFound,
 			"",
 		},
 		{
-			"/film/1",
+			"/actor/1/purge",
 			http.MethodDelete,
 			"",
 			http.StatusInternalServerError,
 			"",
 		},
 		{
-			"/film/1",
+			"/actor/1/purge",
 			http.MethodDelete,
 			"",
 			http.StatusNoContent,
@@ -746,7 +910,7 @@ func TestDeleteFilm(t *testing.T) {
 	}
 }
 
-func TestReadFilms(t *testing.T) {
+func TestReadDeletedActors(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -759,70 +923,49 @@ func TestReadFilms(t *testing.T) {
 		body     string
 	}{
 		{
-			"/films?field=abc",
-			http.MethodGet,
-			"",
-			http.StatusBadRequest,
-			"",
-		},
-		{
-			"/films?order=abc",
-			http.MethodGet,
-			"",
-			http.StatusBadRequest,
-			"",
-		},
-		{
-			"/films?page=abc",
-			http.MethodGet,
-			"",
-			http.StatusBadRequest,
-			"",
-		},
-		{
-			"/films?limit=abc",
+			"/actors/deleted?page=0",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films?page=0",
+			"/actors/deleted?limit=abc",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films?limit=0",
+			"/actors/deleted",
 			http.MethodGet,
 			"",
-			http.StatusBadRequest,
+			http.StatusInternalServerError,
 			"",
 		},
 		{
-			"/films?limit=101",
+			"/actors/deleted?after=abc",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films",
+			"/actors/deleted",
 			http.MethodGet,
 			"",
-			http.StatusInternalServerError,
+			http.StatusNoContent,
 			"",
 		},
 		{
-			"/films",
+			"/actors/deleted",
 			http.MethodGet,
 			"",
-			http.StatusNoContent,
+			http.StatusOK,
 			"",
 		},
 		{
-			"/films",
+			"/actors/deleted?after=",
 			http.MethodGet,
 			"",
 			http.StatusOK,
@@ -836,7 +979,7 @@ func TestReadFilms(t *testing.T) {
 	}
 }
 
-func TestFindFilms(t *testing.T) {
+func TestReadActorHistory(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -849,56 +992,49 @@ func TestFindFilms(t *testing.T) {
 		body     string
 	}{
 		{
-			"/films/search?page=abc",
-			http.MethodGet,
-			"",
-			http.StatusBadRequest,
-			"",
-		},
-		{
-			"/films/search?limit=abc",
+			"/actor/abc/history",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films/search?page=0",
+			"/actor/1/history?page=0",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films/search?limit=101",
+			"/actor/1/history",
 			http.MethodGet,
 			"",
-			http.StatusBadRequest,
+			http.StatusInternalServerError,
 			"",
 		},
 		{
-			"/films/search",
+			"/actor/1/history?after=abc",
 			http.MethodGet,
 			"",
 			http.StatusBadRequest,
 			"",
 		},
 		{
-			"/films/search?title=a",
+			"/actor/1/history",
 			http.MethodGet,
 			"",
-			http.StatusInternalServerError,
+			http.StatusNoContent,
 			"",
 		},
 		{
-			"/films/search?title=a",
+			"/actor/1/history",
 			http.MethodGet,
 			"",
-			http.StatusNoContent,
+			http.StatusOK,
 			"",
 		},
 		{
-			"/films/search?title=a",
+			"/actor/1/history?after=",
 			http.MethodGet,
 			"",
 			http.StatusOK,
@@ -912,7 +1048,7 @@ func TestFindFilms(t *testing.T) {
 	}
 }
 
-func TestRegister(t *testing.T) {
+func TestReadActor(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -925,67 +1061,32 @@ func TestRegister(t *testing.T) {
 		body     string
 	}{
 		{
-			"/register",
-			http.MethodPost,
+			"/actor/abc",
+			http.MethodGet,
 			"",
 			http.StatusBadRequest,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
-		},
-		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}",
-		},
-		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"login\":\"abc\",\"password\":\"abc\"",
-		},
-		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusBadRequest,
-			"{\"login\":1,\"password\":\"abc\"}",
-		},
-		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusConflict,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
-		},
-		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"/actor/1",
+			http.MethodGet,
+			"",
+			http.StatusNotFound,
+			"",
 		},
 		{
-			"/register",
-			http.MethodPost,
-			"application/json",
+			"/actor/1",
+			http.MethodGet,
+			"",
 			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/register",
-			http.MethodPost,
-			"application/json",
-			http.StatusCreated,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"/actor/1",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
 		},
 	}
 
@@ -995,7 +1096,7 @@ func TestRegister(t *testing.T) {
 	}
 }
 
-func TestLogIn(t *testing.T) {
+func TestReadActors(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
@@ -1008,67 +1109,3063 @@ func TestLogIn(t *testing.T) {
 		body     string
 	}{
 		{
-			"/login",
-			http.MethodPost,
+			"/actors?page=1&after=",
+			http.MethodGet,
 			"",
 			http.StatusBadRequest,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/login",
-			http.MethodPost,
-			"application/json",
+			"/actors?field=abc",
+			http.MethodGet,
+			"",
 			http.StatusBadRequest,
-			"{\"login\":\"abc\",\"password\":\"abc\"",
+			"",
 		},
 		{
-			"/login",
-			http.MethodPost,
-			"application/json",
+			"/actors?order=abc",
+			http.MethodGet,
+			"",
 			http.StatusBadRequest,
-			"{\"login\":1,\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/login",
-			http.MethodPost,
-			"application/json",
-			http.StatusUnauthorized,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"/actors?page=a",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
 		},
 		{
-			"/login",
-			http.MethodPost,
+			"/actors?limit=a",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors?page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors?limit=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors?limit=101",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors?page=1&limit=10",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/actors?page=1&limit=10",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/actors?page=1&limit=10",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/actors?after=",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/actors?after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestFindActors(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/actors/search?name=abc&page=2&after=",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?gender=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?bornFrom=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?bornTo=01.01.2000",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?bornFrom=2000-01-02&bornTo=2000-01-01",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc&page=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc&limit=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc&page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc&limit=101",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc&fuzzy=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/actors/search?name=abc",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/actors/search?name=abc&fuzzy=true",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/actors/search?gender=female&bornFrom=1970-01-01&bornTo=2000-01-01&film=abc",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestCreateFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":5.7,\"actorIDs\":[3],\"cast\":[{\"actorID\":3}]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":5.7,\"cast\":[{\"actorID\":3,\"role\":\"abc\"}]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":5.7,\"cast\":[{\"actorID\":3,\"billing\":-1}]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":5.7,\"cast\":[{\"actorID\":3},{\"actorID\":3}]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":5.7,\"cast\":[{\"actorID\":3,\"character\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"abc\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": \"\",\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [\"3\", \"4\"]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"a\",\"releaseDate\": \"2021-04-13\",\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"a\",\"releaseDate\": \"2021-04-13\",\"rating\":12,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusNotFound,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2019-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestUpdateFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusPreconditionFailed,
+			"{\"title\":\"a\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\",\"rating\":1}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\":\"test\",\"releaseDate\":\"2021-04-13\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"actorIDs\":[3],\"cast\":[{\"actorID\":3}]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"cast\":[{\"actorID\":3,\"role\":\"abc\"}]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"actorIDs\":[3,3]}",
+		},
+		{
+			"/film/",
+			http.MethodPut,
+			"",
+			http.StatusNotFound,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/abc",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\",\"releaseDate\": \"2021-04-13\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2\",\"rating\": 5.7,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 12,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusNotFound,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusNotFound,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actors\": [3, 4]}",
+		},
+		{
+			"/film/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusNoContent,
+			"{\"title\":\"a\",\"description\": \"test\",\"releaseDate\": \"2021-04-13\",\"rating\": 1,\"actorIDs\": [3, 4]}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestPatchFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusPreconditionFailed,
+			"{\"title\":\"a\"}",
+		},
+		{
+			"/film/abc",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"text/plain",
+			http.StatusBadRequest,
+			"{\"title\":\"abc\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"title\":null}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"releaseDate\":null}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"rating\":null}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"title\":\"\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"title\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"description\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"releaseDate\":\"2\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"rating\":12}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"actorIDs\":[3],\"cast\":null}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"actorIDs\":[3,3]}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"cast\":[{\"actorID\":3,\"role\":\"abc\"}]}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusBadRequest,
+			"{\"releaseDate\":\"1900-01-01\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusNotFound,
+			"{\"actorIDs\":[3]}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusNotFound,
+			"{\"title\":\"a\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusInternalServerError,
+			"{\"title\":\"a\"}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusNoContent,
+			"{\"description\":null,\"actorIDs\":null,\"genres\":null}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/merge-patch+json",
+			http.StatusNoContent,
+			"{\"cast\":[{\"actorID\":3,\"role\":\"lead\"}],\"genres\":[1]}",
+		},
+		{
+			"/film/1",
+			http.MethodPatch,
+			"application/json",
+			http.StatusNoContent,
+			"{\"rating\":1}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRevertFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/revert/1",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/revert/abc",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusPreconditionFailed,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1/revert/2",
+			http.MethodPost,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestDeleteFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/1",
+			http.MethodDelete,
+			"",
+			http.StatusPreconditionFailed,
+			"",
+		},
+		{
+			"/film/a",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRestoreFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/restore",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/restore",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1/restore",
+			http.MethodPost,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1/restore",
+			http.MethodPost,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestPurgeFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/purge",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/purge",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1/purge",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1/purge",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadDeletedFilms(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/films/deleted?page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/deleted?limit=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/deleted",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/films/deleted?after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/deleted",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/films/deleted",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/films/deleted?after=",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadFilmHistory(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/history",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/history?page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/history",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1/history?after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/history",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/film/1/history",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/film/1/history?after=",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadFilm(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodGet,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestAddCrewCredit(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"personID\":1,\"job\":\"director\"",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"personID\":1,\"job\":\"abc\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusPreconditionFailed,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusNotFound,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusNotFound,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusConflict,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+		{
+			"/film/1/crew",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"personID\":1,\"job\":\"director\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestDeleteCrewCredit(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/film/abc/crew/1/director",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/crew/abc/director",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/crew/1/abc",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/film/1/crew/1/director",
+			http.MethodDelete,
+			"",
+			http.StatusPreconditionFailed,
+			"",
+		},
+		{
+			"/film/1/crew/1/director",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/film/1/crew/1/director",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/film/1/crew/1/director",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadFilms(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/films?page=1&after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?releasedFrom=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?releasedTo=2000-13-01",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?releasedFrom=2000-01-02&releasedTo=2000-01-01",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?minRating=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?maxRating=11",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?minRating=NaN",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?minRating=9&maxRating=8",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?actorID=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?actorID=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?field=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?order=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?page=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?limit=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?limit=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films?limit=101",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/films",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/films?releasedFrom=1990-01-01&releasedTo=1999-12-31&minRating=8&maxRating=10&actorID=1",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/films?after=",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/films?after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestFindFilms(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/films/search?title=abc&page=2&after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?title=abc&fuzzy=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?page=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?limit=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?page=0",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?limit=101",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/films/search?title=a",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/films/search?title=a",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/films/search?title=a",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestCreateGenre(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/genre",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"drama\"",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"drama\",\"name\":\"drama\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusConflict,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"name\":\"drama\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestUpdateGenre(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/genre/abc",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"",
+			http.StatusBadRequest,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusNotFound,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusConflict,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"name\":\"drama\"}",
+		},
+		{
+			"/genre/1",
+			http.MethodPut,
+			"application/json",
+			http.StatusNoContent,
+			"{\"name\":\"drama\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestDeleteGenre(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/genre/abc",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/genre/1",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/genre/1",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/genre/1",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadGenres(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/genres",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/genres",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/genres",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/register",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"Aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":1,\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"ab\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"a b c\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abcdefgh\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abcdefg1\",\"password\":\"AbcDefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"Password1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusConflict,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+		{
+			"/register",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"login\":\"abc\",\"password\":\"Abcdefg1\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRegisterPolicyViolations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewAuthorization(service.NewAuthorization(mocks.NewMockAuthorizationRepository(ctrl), testCredentialsPolicy, testLoginThrottlePolicy), "", time.Minute, time.Hour, time.Hour)
+
+	r := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"login":"a!","password":"a!"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.Register(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, violation := range []error{
+		appErrors.ErrLoginTooShort,
+		appErrors.ErrLoginHasForbiddenCharacter,
+		appErrors.ErrPasswordTooShort,
+		appErrors.ErrPasswordHasNoUppercase,
+		appErrors.ErrPasswordHasNoDigit,
+		appErrors.ErrPasswordEqualsLogin,
+	} {
+		require.Contains(t, w.Body.String(), violation.Error())
+	}
+
+	require.NotContains(t, w.Body.String(), appErrors.ErrPasswordHasNoLowercase.Error())
+}
+
+func TestLogIn(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/login",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abc\"",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":1,\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusTooManyRequests,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusForbidden,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/login",
+			http.MethodPost,
+			"application/json",
+			http.StatusOK,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"refreshToken\":1}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/token/refresh",
+			http.MethodPost,
+			"application/json",
+			http.StatusOK,
+			"{\"refreshToken\":\"abc\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestLogOut(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/logout",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"",
+		},
+		{
+			"/logout",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/logout",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"refreshToken\":\"abc\"}",
+		},
+		{
+			"/logout",
+			http.MethodPost,
+			"application/json",
+			http.StatusNoContent,
+			"{\"refreshToken\":\"abc\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+
+	token, err := jwt.CreateJWT("abc", "viewer", nil, []byte(""), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// the refresh token is optional with a valid access token, which is revoked before anything else
+	for _, testCase := range []struct {
+		code int
+		body string
+	}{
+		{http.StatusInternalServerError, "{\"refreshToken\":\"abc\"}"},
+		{http.StatusNoContent, "{\"refreshToken\":\"abc\"}"},
+		{http.StatusNoContent, ""},
+		{http.StatusBadRequest, "{\"abc\":\"abc\"}"},
+	} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/logout", strings.NewReader(testCase.body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, testCase.code, resp.StatusCode)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/password",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":1,\"password\":\"abc\",\"newPassword\":\"abcd\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abc\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"abc\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusTooManyRequests,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusForbidden,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password",
+			http.MethodPost,
+			"application/json",
+			http.StatusOK,
+			"{\"login\":\"abc\",\"password\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/password/reset",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"resetToken\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"resetToken\":1}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"newPassword\":\"abcd\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"resetToken\":\"abc\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"resetToken\":\"abc\",\"newPassword\":\"password\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusUnauthorized,
+			"{\"resetToken\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"resetToken\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+		{
+			"/password/reset",
+			http.MethodPost,
+			"application/json",
+			http.StatusNoContent,
+			"{\"resetToken\":\"abc\",\"newPassword\":\"Abcdefg1\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestCreatePasswordResetToken(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/abc/password/reset",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/user/abc/password/reset",
+			http.MethodPost,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/user/abc/password/reset",
+			http.MethodPost,
+			"",
+			http.StatusCreated,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadLockouts(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/lockouts",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/lockouts",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/lockouts",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/abc/unlock",
+			http.MethodPost,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/user/abc/unlock",
+			http.MethodPost,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/user/admin/unlock",
+			http.MethodPost,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/apikey",
+			http.MethodPost,
+			"",
+			http.StatusBadRequest,
+			"{\"name\":\"import\",\"scope\":\"read\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"import\",\"scope\":\"read\",\"abc\":1}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"scope\":\"read\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"import\",\"scope\":\"admin\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"name\":\"import\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusInternalServerError,
+			"{\"name\":\"import\",\"scope\":\"read\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusConflict,
+			"{\"name\":\"import\",\"scope\":\"read\"}",
+		},
+		{
+			"/apikey",
+			http.MethodPost,
+			"application/json",
+			http.StatusCreated,
+			"{\"name\":\"import\",\"scope\":\"read\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadAPIKeys(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/apikeys",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/apikeys",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/apikeys",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/apikey/abc",
+			http.MethodDelete,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/apikey/1",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/apikey/1",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/apikey/1",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadUsers(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/users?page=a",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/users?after=abc",
+			http.MethodGet,
+			"",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"/users",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/users",
+			http.MethodGet,
+			"",
+			http.StatusNoContent,
+			"",
+		},
+		{
+			"/users",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"/users?after=",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestReadRoles(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/roles",
+			http.MethodGet,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/roles",
+			http.MethodGet,
+			"",
+			http.StatusOK,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/abc/role",
+			http.MethodPut,
+			"",
+			http.StatusBadRequest,
+			"{\"role\":\"editor\"}",
+		},
+		{
+			"/user/abc/role",
+			http.MethodPut,
 			"application/json",
-			http.StatusUnauthorized,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			http.StatusBadRequest,
+			"{\"role\":1}",
 		},
 		{
-			"/login",
-			http.MethodPost,
+			"/user/abc/role",
+			http.MethodPut,
+			"application/json",
+			http.StatusBadRequest,
+			"{\"role\":\"\"}",
+		},
+		{
+			"/user/admin/role",
+			http.MethodPut,
+			"application/json",
+			http.StatusConflict,
+			"{\"role\":\"editor\"}",
+		},
+		{
+			"/user/abc/role",
+			http.MethodPut,
+			"application/json",
+			http.StatusNotFound,
+			"{\"role\":\"editor\"}",
+		},
+		{
+			"/user/abc/role",
+			http.MethodPut,
+			"application/json",
+			http.StatusNotFound,
+			"{\"role\":\"editor\"}",
+		},
+		{
+			"/user/abc/role",
+			http.MethodPut,
 			"application/json",
 			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"{\"role\":\"editor\"}",
 		},
 		{
-			"/login",
-			http.MethodPost,
+			"/user/abc/role",
+			http.MethodPut,
 			"application/json",
+			http.StatusNoContent,
+			"{\"role\":\"editor\"}",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/admin/disable",
+			http.MethodPost,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/user/abc/disable",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/user/abc/disable",
+			http.MethodPost,
+			"",
 			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/login",
+			"/user/abc/disable",
 			http.MethodPost,
-			"application/json",
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestEnableUser(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/abc/enable",
+			http.MethodPost,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/user/abc/enable",
+			http.MethodPost,
+			"",
 			http.StatusInternalServerError,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
 		},
 		{
-			"/login",
+			"/user/admin/enable",
 			http.MethodPost,
-			"application/json",
-			http.StatusOK,
-			"{\"login\":\"abc\",\"password\":\"abc\"}",
+			"",
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint)
+		resp.Body.Close()
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+		content  string
+		code     int
+		body     string
+	}{
+		{
+			"/user/admin",
+			http.MethodDelete,
+			"",
+			http.StatusConflict,
+			"",
+		},
+		{
+			"/user/abc",
+			http.MethodDelete,
+			"",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"/user/abc",
+			http.MethodDelete,
+			"",
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"/user/abc",
+			http.MethodDelete,
+			"",
+			http.StatusNoContent,
+			"",
 		},
 	}
 
@@ -1077,3 +4174,152 @@ func TestLogIn(t *testing.T) {
 		resp.Body.Close()
 	}
 }
+
+func TestWritePaginationHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/films?page=2&limit=10&genre=drama", nil)
+
+	writePaginationHeaders(w, r, domain.Pagination{Page: 2, Limit: 10}, domain.PageInfo{Total: 35, NextCursor: "abc"})
+	require.Equal(t, "35", w.Header().Get("X-Total-Count"))
+	require.Equal(t, `</films?genre=drama&limit=10&page=1>; rel="first", </films?genre=drama&limit=10&page=1>; rel="prev", </films?genre=drama&limit=10&page=3>; rel="next", </films?genre=drama&limit=10&page=4>; rel="last"`, w.Header().Get("Link"))
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/actors?after=abc", nil)
+
+	writePaginationHeaders(w, r, domain.Pagination{Page: 1, Limit: 15, After: "abc"}, domain.PageInfo{Total: 20, NextCursor: "def"})
+	require.Equal(t, "20", w.Header().Get("X-Total-Count"))
+	require.Equal(t, `</actors?after=&limit=15>; rel="first", </actors?after=def&limit=15>; rel="next"`, w.Header().Get("Link"))
+}
+
+func TestIfMatch(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/film/1", nil)
+	_, err := ifMatch(r)
+	require.ErrorIs(t, err, appErrors.ErrNoIfMatchProvided)
+
+	r.Header.Set("If-Match", "*")
+	version, err := ifMatch(r)
+	require.NoError(t, err)
+	require.Zero(t, version)
+
+	r.Header.Set("If-Match", etag(3, time.Now()))
+	version, err = ifMatch(r)
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+
+	r.Header.Set("If-Match", `"4"`)
+	version, err = ifMatch(r)
+	require.NoError(t, err)
+	require.Equal(t, 4, version)
+
+	for _, header := range []string{"3", `W/"3"`, `"abc"`, `"0"`, `"1", "2"`} {
+		r.Header.Set("If-Match", header)
+		_, err = ifMatch(r)
+		require.ErrorIs(t, err, appErrors.ErrWrongIfMatch)
+	}
+}
+
+func TestIfMatchRequired(t *testing.T) {
+	ts := httptest.NewServer(testRouter(t))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint string
+		method   string
+	}{
+		{"/actor/1", http.MethodPut},
+		{"/actor/1", http.MethodPatch},
+		{"/actor/1", http.MethodDelete},
+		{"/actor/1/revert/1", http.MethodPost},
+		{"/film/1", http.MethodPut},
+		{"/film/1", http.MethodPatch},
+		{"/film/1", http.MethodDelete},
+		{"/film/1/revert/1", http.MethodPost},
+		{"/film/1/crew", http.MethodPost},
+		{"/film/1/crew/1/director", http.MethodDelete},
+	}
+
+	for _, testCase := range testTable {
+		req, err := http.NewRequest(testCase.method, ts.URL+testCase.endpoint, strings.NewReader("{}"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusPreconditionRequired, resp.StatusCode, testCase.method+" "+testCase.endpoint)
+	}
+}
+
+func TestWriteCacheableJSON(t *testing.T) {
+	lastModified := time.Date(2024, time.March, 10, 12, 30, 15, 500, time.UTC)
+	film := domain.OutputFilm{ID: 1, Title: "abc", Version: 2, LastModified: lastModified}
+	film.ETag = etag(film.Version, film.LastModified)
+
+	r := httptest.NewRequest(http.MethodGet, "/film/1", nil)
+	w := httptest.NewRecorder()
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, film.ETag, w.Header().Get("ETag"))
+	require.Equal(t, "Sun, 10 Mar 2024 12:30:15 GMT", w.Header().Get("Last-Modified"))
+	require.Contains(t, w.Body.String(), `"etag":"\"2-`)
+
+	r.Header.Set("If-None-Match", `"1-abc", `+film.ETag)
+	w = httptest.NewRecorder()
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, "")
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.String())
+
+	r.Header.Set("If-None-Match", `"1-abc"`)
+	r.Header.Set("If-Modified-Since", "Sun, 10 Mar 2024 12:30:15 GMT")
+	w = httptest.NewRecorder()
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	r.Header.Del("If-None-Match")
+	w = httptest.NewRecorder()
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, "")
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	r.Header.Set("If-Modified-Since", "Sun, 10 Mar 2024 12:30:14 GMT")
+	w = httptest.NewRecorder()
+	writeCacheableJSON(w, r, film, film.ETag, film.LastModified, "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, "/films", nil)
+	w = httptest.NewRecorder()
+	w.Header().Set("X-Total-Count", "1")
+	writeCacheableJSON(w, r, []domain.OutputFilm{film}, "", time.Time{}, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Last-Modified"))
+	listETag := w.Header().Get("ETag")
+	require.NotEmpty(t, listETag)
+
+	w = httptest.NewRecorder()
+	w.Header().Set("X-Total-Count", "2")
+	writeCacheableJSON(w, r, []domain.OutputFilm{film}, "", time.Time{}, "")
+	require.NotEqual(t, listETag, w.Header().Get("ETag"))
+
+	r.Header.Set("If-None-Match", "W/"+listETag)
+	w = httptest.NewRecorder()
+	w.Header().Set("X-Total-Count", "1")
+	writeCacheableJSON(w, r, []domain.OutputFilm{film}, "", time.Time{}, "")
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	// a list is not cached by time, a record could have left the page since then
+	r.Header.Del("If-None-Match")
+	r.Header.Set("If-Modified-Since", "Sun, 10 Mar 2024 12:30:15 GMT")
+	w = httptest.NewRecorder()
+	w.Header().Set("X-Total-Count", "1")
+	writeCacheableJSON(w, r, []domain.OutputFilm{film}, "", time.Time{}, "")
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestWriteRetryAfter(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeRetryAfter(w, &appErrors.RetryAfterError{Err: appErrors.ErrTooManyLoginAttempts, RetryAfter: 1500 * time.Millisecond}, "")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("Retry-After"))
+	require.Equal(t, appErrors.ErrTooManyLoginAttempts.Error(), w.Body.String())
+}
@@ -1,65 +1,111 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
-	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v4"
+	"go.uber.org/zap"
 
 	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
 	httperrorwriter "github.com/PoorMercymain/filmoteka/pkg/http-error-writer"
 	"github.com/PoorMercymain/filmoteka/pkg/jwt"
+	"github.com/PoorMercymain/filmoteka/pkg/logger"
 )
 
-func AdminRequired(next http.Handler, jwtKey string) http.Handler {
+func RequirePermission(next http.Handler, permission string, jwtKey string, srv domain.AuthorizationService) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		const logErrPrefix = "middleware.AdminRequired():"
-
-		authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
-		if authToken == "" {
-			cookie, err := r.Cookie("authToken")
-			if err != nil {
-				httperrorwriter.WriteError(w, appErrors.ErrNoTokenProvided, http.StatusUnauthorized, logErrPrefix)
-				return
-			}
-
-			authToken = cookie.Value
-		}
+		const logErrPrefix = "middleware.RequirePermission():"
 
-		isAdmin, err := jwt.CheckIsAdminInJWT(authToken, jwtKey)
-		if err != nil {
-			httperrorwriter.WriteError(w, appErrors.ErrTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+		claims, ok := authorize(w, r, jwtKey, srv, logErrPrefix)
+		if !ok {
 			return
 		}
 
-		if !isAdmin {
-			httperrorwriter.WriteError(w, appErrors.ErrAdminRequired, http.StatusForbidden, logErrPrefix)
+		setActor(r, claims.Subject)
+
+		if !claims.HasPermission(permission) {
+			httperrorwriter.WriteError(w, fmt.Errorf("%w: %s", appErrors.ErrPermissionDenied, permission), http.StatusForbidden, logErrPrefix)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(domain.WithLogin(r.Context(), claims.Subject)))
 	})
 }
 
-func AuthorizationRequired(next http.Handler, jwtKey string) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		const logErrPrefix = "middleware.AuthorizationRequired():"
+func authorize(w http.ResponseWriter, r *http.Request, jwtKey string, srv domain.AuthorizationService, logErrPrefix string) (*jwt.Claims, bool) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return authorizeAPIKey(w, r, key, srv, logErrPrefix)
+	}
 
-		authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
-		if authToken == "" {
-			cookie, err := r.Cookie("authToken")
-			if err != nil {
-				httperrorwriter.WriteError(w, appErrors.ErrNoTokenProvided, http.StatusUnauthorized, logErrPrefix)
-				return
-			}
+	authToken, err := jwt.FromRequest(r)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrNoTokenProvided, http.StatusUnauthorized, logErrPrefix)
+		return nil, false
+	}
 
-			authToken = cookie.Value
-		}
+	claims, err := jwt.ParseJWT(authToken, jwtKey)
+	if err != nil {
+		httperrorwriter.WriteError(w, appErrors.ErrTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+		return nil, false
+	}
+
+	isRevoked, err := srv.IsTokenRevoked(r.Context(), claims.ID)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return nil, false
+	}
+
+	if isRevoked {
+		httperrorwriter.WriteError(w, appErrors.ErrTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
+		return nil, false
+	}
+
+	// the token stays valid until it expires, so the account is checked on every request
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
 
-		_, err := jwt.CheckIsAdminInJWT(authToken, jwtKey)
-		if err != nil {
+	err = srv.CheckSession(r.Context(), claims.Subject, issuedAt)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) || errors.Is(err, appErrors.ErrTokenIsInvalid) {
 			httperrorwriter.WriteError(w, appErrors.ErrTokenIsInvalid, http.StatusUnauthorized, logErrPrefix)
-			return
+			return nil, false
 		}
 
-		next.ServeHTTP(w, r)
-	})
+		if errors.Is(err, appErrors.ErrUserIsDisabled) {
+			httperrorwriter.WriteError(w, appErrors.ErrUserIsDisabled, http.StatusForbidden, logErrPrefix)
+			return nil, false
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+func authorizeAPIKey(w http.ResponseWriter, r *http.Request, key string, srv domain.AuthorizationService, logErrPrefix string) (*jwt.Claims, bool) {
+	apiKey, err := srv.CheckAPIKey(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrAPIKeyIsInvalid) {
+			httperrorwriter.WriteError(w, appErrors.ErrAPIKeyIsInvalid, http.StatusUnauthorized, logErrPrefix)
+			return nil, false
+		}
+
+		logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+		httperrorwriter.WriteError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return nil, false
+	}
+
+	return &jwt.Claims{
+		RegisteredClaims: &gojwt.RegisteredClaims{Subject: apiKey.Login()},
+		Permissions:      domain.APIKeyScopes[apiKey.Scope],
+	}, true
 }
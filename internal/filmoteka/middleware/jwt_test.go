@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain/mocks"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/handlers"
 	"github.com/PoorMercymain/filmoteka/internal/filmoteka/service"
@@ -24,11 +27,28 @@ func testRouter(t *testing.T) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	aur := mocks.NewMockAuthorizationRepository(ctrl)
-	aus := service.NewAuthorization(aur)
-	auh := handlers.NewAuthorization(aus, "")
+	aus := service.NewAuthorization(aur, domain.CredentialsPolicy{}, domain.LoginThrottlePolicy{})
+	auh := handlers.NewAuthorization(aus, "", time.Minute, time.Hour, time.Hour)
 
-	mux.Handle("GET /admin", AdminRequired(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), auh.JWTKey))
-	mux.Handle("GET /user", AuthorizationRequired(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), auh.JWTKey))
+	aur.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Return(false, errors.New("")).MaxTimes(1)
+	aur.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Return(true, nil).MaxTimes(1)
+	aur.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	sessionsRevokedAt := time.Now().Add(time.Hour)
+
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{}, appErrors.ErrUserNotFound).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{IsDisabled: true}, nil).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{SessionsRevokedAt: &sessionsRevokedAt}, nil).MaxTimes(1)
+	aur.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(domain.Account{}, nil).AnyTimes()
+
+	// the handler fails if the login from the token is not passed to it
+	checkLogin := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if domain.LoginFromContext(r.Context()) != "login" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	mux.Handle("PUT /film", RequirePermission(checkLogin, domain.PermissionFilmUpdate, auh.JWTKey, aus))
 
 	return mux
 }
@@ -57,18 +77,24 @@ func request(t *testing.T, ts *httptest.Server, code int, method, content, body,
 	return resp
 }
 
-func TestAdminRequired(t *testing.T) {
+func TestRequirePermission(t *testing.T) {
 	ts := httptest.NewServer(testRouter(t))
 
 	defer ts.Close()
 
-	tokenStrNoAdmin, err := jwt.CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
+	viewerToken, err := jwt.CreateJWT("login", "viewer", []string{domain.PermissionFilmRead}, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	editorToken, err := jwt.CreateJWT("login", "editor", []string{domain.PermissionFilmRead, domain.PermissionFilmUpdate}, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	tokenStrAdmin, err := jwt.CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
+	noPermissionsToken, err := jwt.CreateJWT("login", "", nil, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	wrongToken, err := jwt.CreateJWT(true, []byte("abcd"), time.Now().Add(24*time.Hour))
+	wrongToken, err := jwt.CreateJWT("login", "editor", []string{domain.PermissionFilmUpdate}, []byte("abcd"), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	noLoginToken, err := jwt.CreateJWT("", "editor", []string{domain.PermissionFilmUpdate}, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
 	var testTable = []struct {
@@ -81,84 +107,62 @@ func TestAdminRequired(t *testing.T) {
 		cookie        string
 	}{
 		{
-			"/admin",
-			http.MethodGet,
-			"",
-			http.StatusUnauthorized,
+			"/film",
+			http.MethodPut,
 			"",
+			http.StatusInternalServerError,
 			"",
+			editorToken,
 			"",
 		},
 		{
-			"/admin",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
-			http.StatusForbidden,
+			http.StatusUnauthorized,
 			"",
-			tokenStrNoAdmin,
+			editorToken,
 			"",
 		},
 		{
-			"/admin",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
 			http.StatusUnauthorized,
 			"",
-			wrongToken,
+			editorToken,
 			"",
 		},
 		{
-			"/admin",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
-			http.StatusOK,
+			http.StatusInternalServerError,
 			"",
-			tokenStrAdmin,
+			editorToken,
 			"",
 		},
 		{
-			"/admin",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
-			http.StatusOK,
+			http.StatusForbidden,
 			"",
+			editorToken,
+			"",
+		},
+		{
+			"/film",
+			http.MethodPut,
+			"",
+			http.StatusUnauthorized,
+			"",
+			editorToken,
 			"",
-			tokenStrAdmin,
 		},
-	}
-
-	for _, testCase := range testTable {
-		resp := request(t, ts, testCase.code, testCase.method, testCase.content, testCase.body, testCase.endpoint, testCase.authorization, testCase.cookie)
-		resp.Body.Close()
-	}
-}
-
-func TestAuthorizationRequired(t *testing.T) {
-	ts := httptest.NewServer(testRouter(t))
-
-	defer ts.Close()
-
-	tokenStrNoAdmin, err := jwt.CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
-	require.NoError(t, err)
-
-	tokenStrAdmin, err := jwt.CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
-	require.NoError(t, err)
-
-	wrongToken, err := jwt.CreateJWT(true, []byte("abcd"), time.Now().Add(24*time.Hour))
-	require.NoError(t, err)
-
-	var testTable = []struct {
-		endpoint      string
-		method        string
-		content       string
-		code          int
-		body          string
-		authorization string
-		cookie        string
-	}{
 		{
-			"/user",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
 			http.StatusUnauthorized,
 			"",
@@ -166,8 +170,17 @@ func TestAuthorizationRequired(t *testing.T) {
 			"",
 		},
 		{
-			"/user",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
+			"",
+			http.StatusUnauthorized,
+			"",
+			noLoginToken,
+			"",
+		},
+		{
+			"/film",
+			http.MethodPut,
 			"",
 			http.StatusUnauthorized,
 			"",
@@ -175,31 +188,40 @@ func TestAuthorizationRequired(t *testing.T) {
 			"",
 		},
 		{
-			"/user",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
-			http.StatusOK,
+			http.StatusForbidden,
 			"",
-			tokenStrNoAdmin,
+			viewerToken,
 			"",
 		},
 		{
-			"/user",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
+			"",
+			http.StatusForbidden,
+			"",
+			noPermissionsToken,
+			"",
+		},
+		{
+			"/film",
+			http.MethodPut,
 			"",
 			http.StatusOK,
 			"",
-			tokenStrAdmin,
+			editorToken,
 			"",
 		},
 		{
-			"/user",
-			http.MethodGet,
+			"/film",
+			http.MethodPut,
 			"",
 			http.StatusOK,
 			"",
 			"",
-			tokenStrAdmin,
+			editorToken,
 		},
 	}
 
@@ -208,3 +230,41 @@ func TestAuthorizationRequired(t *testing.T) {
 		resp.Body.Close()
 	}
 }
+
+func TestRequirePermissionAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aur := mocks.NewMockAuthorizationRepository(ctrl)
+	aus := service.NewAuthorization(aur, domain.CredentialsPolicy{}, domain.LoginThrottlePolicy{})
+
+	aur.EXPECT().CheckAPIKey(gomock.Any(), gomock.Any()).Return(domain.APIKey{}, errors.New("")).MaxTimes(1)
+	aur.EXPECT().CheckAPIKey(gomock.Any(), gomock.Any()).Return(domain.APIKey{}, appErrors.ErrAPIKeyIsInvalid).MaxTimes(1)
+	aur.EXPECT().CheckAPIKey(gomock.Any(), gomock.Any()).Return(domain.APIKey{ID: 1, Name: "import", Scope: domain.APIKeyScopeRead}, nil).MaxTimes(1)
+	aur.EXPECT().CheckAPIKey(gomock.Any(), gomock.Any()).Return(domain.APIKey{ID: 1, Name: "import", Scope: domain.APIKeyScopeWrite}, nil).MaxTimes(1)
+
+	// the handler fails if the id of the key is not passed to it as the login
+	checkLogin := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if domain.LoginFromContext(r.Context()) != "api-key:1" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("PUT /film", RequirePermission(checkLogin, domain.PermissionFilmUpdate, "", aus))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	for _, code := range []int{http.StatusInternalServerError, http.StatusUnauthorized, http.StatusForbidden, http.StatusOK} {
+		req, err := http.NewRequest(http.MethodPut, ts.URL+"/film", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", "fmk_key")
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, code, resp.StatusCode)
+	}
+}
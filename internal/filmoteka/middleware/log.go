@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -29,16 +30,30 @@ func (irw *informativeResponseWriter) Write(b []byte) (int, error) {
 	return count, err
 }
 
+type actorKey struct{}
+
+// setActor passes the login the request is made by to Log, which is outside of the middleware authorizing the request.
+func setActor(r *http.Request, login string) {
+	if actor, ok := r.Context().Value(actorKey{}).(*string); ok {
+		*actor = login
+	}
+}
+
 func Log(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.Logger().Info("Request HTTP method: ", r.Method, ", request route: ", r.URL.String(), ", length of content in request: ", r.ContentLength)
 
 		irw := NewInformativeResponseWriter(w)
 
+		var actor string
 		start := time.Now()
-		next.ServeHTTP(irw, r)
+		next.ServeHTTP(irw, r.WithContext(context.WithValue(r.Context(), actorKey{}, &actor)))
 		duration := time.Since(start)
 
-		logger.Logger().Info("Response status for ", r.Method, " ", r.URL.String(), ": ", irw.statusCode, ", length of content in response: ", irw.contentLength, ", processing duration: ", duration)
+		if actor != "" {
+			actor = ", made by: " + actor
+		}
+
+		logger.Logger().Info("Response status for ", r.Method, " ", r.URL.String(), ": ", irw.statusCode, ", length of content in response: ", irw.contentLength, ", processing duration: ", duration, actor)
 	})
 }
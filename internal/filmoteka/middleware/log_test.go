@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -60,3 +61,14 @@ func TestLog(t *testing.T) {
 		resp.Body.Close()
 	}
 }
+
+func TestSetActor(t *testing.T) {
+	var actor string
+	r := httptest.NewRequest(http.MethodGet, "/logs", nil)
+
+	setActor(r, "admin")
+	require.Empty(t, actor)
+
+	setActor(r.WithContext(context.WithValue(r.Context(), actorKey{}, &actor)), "api-key:1")
+	require.Equal(t, "api-key:1", actor)
+}
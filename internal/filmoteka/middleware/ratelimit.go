@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	httperrorwriter "github.com/PoorMercymain/filmoteka/pkg/http-error-writer"
+	"github.com/PoorMercymain/filmoteka/pkg/jwt"
+	"github.com/PoorMercymain/filmoteka/pkg/logger"
+	ratelimiter "github.com/PoorMercymain/filmoteka/pkg/rate-limiter"
+)
+
+func RateLimit(next http.Handler, route string, limit ratelimiter.Limit, store ratelimiter.Store, jwtKey string) http.Handler {
+	if limit.IsUnlimited() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const logErrPrefix = "middleware.RateLimit():"
+
+		result, err := store.Take(r.Context(), route+" "+rateLimitKey(r, jwtKey), limit, time.Now())
+		if err != nil {
+			// the limiter being unavailable should not make the whole service unavailable
+			logger.Logger().Errorln(logErrPrefix, zap.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+		w.Header().Set("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+ceilSeconds(limit.Period))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+			httperrorwriter.WriteError(w, appErrors.ErrTooManyRequests, http.StatusTooManyRequests, logErrPrefix)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// A revoked token of a user is still the same user, and an API key is not looked up, so the rejected requests do not reach the database.
+func rateLimitKey(r *http.Request, jwtKey string) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		return "api-key:" + hex.EncodeToString(hash[:])
+	}
+
+	authToken, err := jwt.FromRequest(r)
+	if err == nil {
+		claims, err := jwt.ParseJWT(authToken, jwtKey)
+		if err == nil && claims.Subject != "" {
+			return "login:" + claims.Subject
+		}
+	}
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return "ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
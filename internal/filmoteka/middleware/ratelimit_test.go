@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/filmoteka/pkg/jwt"
+	ratelimiter "github.com/PoorMercymain/filmoteka/pkg/rate-limiter"
+)
+
+func TestRateLimit(t *testing.T) {
+	store := ratelimiter.NewMemoryStore()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /limited", RateLimit(handler, "GET /limited", ratelimiter.Limit{Requests: 2, Period: time.Minute}, store, ""))
+	mux.Handle("GET /unlimited", RateLimit(handler, "GET /unlimited", ratelimiter.Limit{Period: time.Minute}, store, ""))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	token, err := jwt.CreateJWT("abc", "viewer", nil, []byte(""), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	var testTable = []struct {
+		endpoint      string
+		authorization string
+		code          int
+		remaining     string
+		retryAfter    string
+	}{
+		{"/limited", "", http.StatusOK, "1", ""},
+		{"/limited", "", http.StatusOK, "0", ""},
+		{"/limited", "", http.StatusTooManyRequests, "0", "30"},
+		{"/limited", "Bearer " + token, http.StatusOK, "1", ""},
+		{"/limited", "Bearer abc", http.StatusTooManyRequests, "0", "30"},
+		{"/unlimited", "", http.StatusOK, "", ""},
+	}
+
+	for _, testCase := range testTable {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+testCase.endpoint, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", testCase.authorization)
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, testCase.code, resp.StatusCode)
+		require.Equal(t, testCase.remaining, resp.Header.Get("RateLimit-Remaining"))
+		require.Equal(t, testCase.retryAfter, resp.Header.Get("Retry-After"))
+	}
+}
+
+func TestRateLimitAPIKey(t *testing.T) {
+	store := ratelimiter.NewMemoryStore()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /limited", RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), "GET /limited", ratelimiter.Limit{Requests: 2, Period: time.Minute}, store, ""))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var testTable = []struct {
+		apiKey    string
+		code      int
+		remaining string
+	}{
+		{"fmk_first", http.StatusOK, "1"},
+		{"fmk_first", http.StatusOK, "0"},
+		{"fmk_first", http.StatusTooManyRequests, "0"},
+		{"fmk_second", http.StatusOK, "1"},
+		{"", http.StatusOK, "1"},
+	}
+
+	for _, testCase := range testTable {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/limited", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", testCase.apiKey)
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, testCase.code, resp.StatusCode)
+		require.Equal(t, testCase.remaining, resp.Header.Get("RateLimit-Remaining"))
+	}
+}
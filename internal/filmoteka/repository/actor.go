@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -28,7 +29,12 @@ func NewActor(pg *postgres) *actor {
 func (r *actor) CreateActor(ctx context.Context, name string, gender bool, birthday time.Time) (int, error) {
 	var id int
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		return tx.QueryRow(ctx, "INSERT INTO actors(name, gender, birthday) VALUES($1, $2, $3) RETURNING id", name, gender, birthday).Scan(&id)
+		err := tx.QueryRow(ctx, "INSERT INTO actors(name, gender, birthday) VALUES($1, $2, $3) RETURNING id", name, gender, birthday).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionCreate, nil)
 	})
 
 	if err != nil {
@@ -38,15 +44,38 @@ func (r *actor) CreateActor(ctx context.Context, name string, gender bool, birth
 	return id, nil
 }
 
-func (r *actor) UpdateActor(ctx context.Context, id int, name string, gender *bool, birthday time.Time) error {
+func (r *actor) UpdateActor(ctx context.Context, id int, version int, name string, gender bool, birthday time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
+		if err != nil {
+			return err
+		}
+
+		err = updateActor(ctx, tx, id, version, name, gender, birthday)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionUpdate, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.UpdateActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *actor) PatchActor(ctx context.Context, id int, version int, patch domain.ActorPatch) error {
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		var (
-			nameInDB     string
-			genderInDB   bool
-			birthdayInDB time.Time
+			name        string
+			gender      bool
+			birthday    time.Time
+			versionInDB int
 		)
 
-		err := tx.QueryRow(ctx, "SELECT name, gender, birthday FROM actors WHERE id = $1", id).Scan(&nameInDB, &genderInDB, &birthdayInDB)
+		err := tx.QueryRow(ctx, "SELECT name, gender, birthday, version FROM actors WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&name, &gender, &birthday, &versionInDB)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return appErrors.ErrNotFoundInDB
@@ -55,46 +84,121 @@ func (r *actor) UpdateActor(ctx context.Context, id int, name string, gender *bo
 			return err
 		}
 
-		if name == "" {
-			name = nameInDB
+		if version != 0 && version != versionInDB {
+			return appErrors.ErrVersionMismatch
 		}
 
-		if gender == nil {
-			*gender = genderInDB
+		if patch.Name != nil {
+			name = *patch.Name
 		}
 
-		var timeDefault time.Time
-		if birthday == timeDefault {
-			birthday = birthdayInDB
+		if patch.Gender != nil {
+			gender = *patch.Gender
 		}
 
-		_, err = tx.Exec(ctx, "UPDATE actors SET name = $1, gender = $2, birthday = $3 WHERE id = $4", name, *gender, birthday, id)
+		if patch.Birthday != nil {
+			birthday = *patch.Birthday
+		}
+
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		err = updateActor(ctx, tx, id, versionInDB, name, gender, birthday)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionUpdate, before)
 	})
 
 	if err != nil {
-		return fmt.Errorf("repository.UpdateActor(): %w", err)
+		return fmt.Errorf("repository.PatchActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *actor) RevertActor(ctx context.Context, id int, version int, revision int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var state actorState
+		err := readRevision(ctx, tx, domain.AuditEntityActor, id, revision, &state)
+		if err != nil {
+			return err
+		}
+
+		birthday, err := time.Parse(time.DateOnly, state.Birthday)
+		if err != nil {
+			return err
+		}
+
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
+		if err != nil {
+			return err
+		}
+
+		err = updateActor(ctx, tx, id, version, state.Name, state.Gender == "female", birthday)
+		if err != nil {
+			return err
+		}
+
+		// check_actor_birthday_before_film_release only checks new links, so the films the actor is already in are checked here the same way
+		var bornAfterRelease bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM film_actor JOIN films ON films.id = film_actor.film_id WHERE film_actor.actor_id = $1 AND films.deleted_at IS NULL AND films.release_date < $2)", id, birthday).Scan(&bornAfterRelease)
+		if err != nil {
+			return err
+		}
+
+		if bornAfterRelease {
+			return appErrors.ErrActorNotBornBeforeFilmRelease
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionRevert, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.RevertActor(): %w", err)
+	}
+
+	return nil
+}
+
+func updateActor(ctx context.Context, tx pgx.Tx, id int, version int, name string, gender bool, birthday time.Time) error {
+	tag, err := tx.Exec(ctx, "UPDATE actors SET name = $1, gender = $2, birthday = $3, version = version + 1 WHERE id = $4 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)", name, gender, birthday, id, version)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return versionMismatchOrNotFound(ctx, tx, "actors", id)
 	}
 
 	return nil
 }
 
-func (r *actor) DeleteActor(ctx context.Context, id int) error {
+func (r *actor) DeleteActor(ctx context.Context, id int, version int) error {
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		tag, err := tx.Exec(ctx, "DELETE FROM actors WHERE id = $1", id)
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE actors SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)", id, version)
 		if err != nil {
 			return err
 		}
 
 		if tag.RowsAffected() == 0 {
-			return appErrors.ErrNotFoundInDB
+			return versionMismatchOrNotFound(ctx, tx, "actors", id)
 		}
 
-		return nil
+		err = touchActorFilms(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionDelete, before)
 	})
 
 	if err != nil {
@@ -104,71 +208,469 @@ func (r *actor) DeleteActor(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *actor) ReadActors(ctx context.Context, page int, limit int) ([]domain.OutputActor, error) {
-	actors := make([]domain.OutputActor, 0)
-	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
-		rows, err := c.Query(ctx, "SELECT id, name, gender, birthday FROM actors LIMIT $1 OFFSET $2", limit, (page-1)*limit)
+func (r *actor) RestoreActor(ctx context.Context, id int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
 		if err != nil {
 			return err
 		}
 
-		var (
-			curActor    domain.OutputActor
-			curGender   bool
-			curBirthday time.Time
-		)
+		tag, err := tx.Exec(ctx, "UPDATE actors SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
+
+		err = touchActorFilms(ctx, tx, id)
+		if err != nil {
+			return err
+		}
 
+		return writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionRestore, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.RestoreActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *actor) PurgeActor(ctx context.Context, id int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityActor, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, "SELECT film_id FROM film_actor WHERE actor_id = $1 UNION SELECT film_id FROM film_crew WHERE person_id = $1", id)
+		if err != nil {
+			return err
+		}
+
+		var filmIDs []int
 		for rows.Next() {
-			err = rows.Scan(&curActor.ID, &curActor.Name, &curGender, &curBirthday)
+			var filmID int
+			err = rows.Scan(&filmID)
 			if err != nil {
+				rows.Close()
 				return err
 			}
 
-			if curGender {
-				curActor.Gender = "female"
-			} else {
-				curActor.Gender = "male"
+			filmIDs = append(filmIDs, filmID)
+		}
+
+		err = rows.Err()
+		if err != nil {
+			return err
+		}
+
+		filmsBefore := make([][]byte, len(filmIDs))
+		for i, filmID := range filmIDs {
+			filmsBefore[i], err = snapshot(ctx, tx, domain.AuditEntityFilm, filmID)
+			if err != nil {
+				return err
 			}
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE films SET updated_at = now() WHERE id = ANY($1)", filmIDs)
+		if err != nil {
+			return err
+		}
 
-			curActor.Birthday = curBirthday.Format(time.DateOnly)
+		tag, err := tx.Exec(ctx, "DELETE FROM actors WHERE id = $1 AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
+
+		err = writeAudit(ctx, tx, domain.AuditEntityActor, id, domain.AuditActionPurge, before)
+		if err != nil {
+			return err
+		}
 
-			filmRows, err := r.db.Query(ctx, "SELECT film_id FROM film_actor WHERE actor_id = $1", curActor.ID)
+		for i, filmID := range filmIDs {
+			err = writeAudit(ctx, tx, domain.AuditEntityFilm, filmID, domain.AuditActionUpdate, filmsBefore[i])
 			if err != nil {
 				return err
 			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.PurgeActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *actor) ReadDeletedActors(ctx context.Context, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors := make([]domain.OutputActor, 0)
+	var pageInfo domain.PageInfo
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		ks := keyset{keys: []sortKey{{expr: "actors.deleted_at", cast: "timestamptz", desc: true}}, id: "actors.id"}
+
+		var args []any
+		conditions := []string{"actors.deleted_at IS NOT NULL"}
+
+		var err error
+		pageInfo.Total, err = countRows(ctx, c, "actors", conditions, args)
+		if err != nil {
+			return err
+		}
+
+		limitClause, err := ks.paginate(pagination, &conditions, &args)
+		if err != nil {
+			return err
+		}
+
+		sqlStr := fmt.Sprintf("SELECT %s, %s FROM actors WHERE %s ORDER BY %s%s", actorColumns, ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+		rows, err := c.Query(ctx, sqlStr, args...)
+		if err != nil {
+			return err
+		}
+
+		var sortValues [][]string
+		actors, sortValues, err = scanActors(rows, false)
+		if err != nil {
+			return err
+		}
 
-			var (
-				curFilm        domain.ActorOutputFilm
-				curReleaseDate time.Time
-			)
+		if len(actors) > pagination.Limit {
+			actors = actors[:pagination.Limit]
+			pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], actors[pagination.Limit-1].ID)
+		}
+
+		return r.fillFilms(ctx, c, actors)
+	})
 
-			films := make([]domain.ActorOutputFilm, 0)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadDeletedActors(): %w", err)
+	}
 
-			for filmRows.Next() {
-				err = filmRows.Scan(&curFilm.ID)
-				if err != nil {
-					return err
-				}
+	return actors, pageInfo, nil
+}
 
-				err = r.db.QueryRow(ctx, "SELECT title, description, release_date, rating FROM films WHERE id = $1", curFilm.ID).Scan(&curFilm.Title, &curFilm.Description, &curReleaseDate, &curFilm.Rating)
-				if err != nil {
-					return err
-				}
+func (r *actor) ReadActorHistory(ctx context.Context, id int, pagination domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	var (
+		records  []domain.AuditRecord
+		pageInfo domain.PageInfo
+	)
 
-				curFilm.ReleaseDate = curReleaseDate.Format(time.DateOnly)
-				films = append(films, curFilm)
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		var err error
+		records, pageInfo, err = readHistory(ctx, c, domain.AuditEntityActor, id, pagination)
+		return err
+	})
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadActorHistory(): %w", err)
+	}
+
+	return records, pageInfo, nil
+}
+
+func (r *actor) ReadActor(ctx context.Context, id int) (domain.OutputActor, error) {
+	var actor domain.OutputActor
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		var (
+			gender   bool
+			birthday time.Time
+		)
+
+		err := c.QueryRow(ctx, "SELECT "+actorColumns+" FROM actors WHERE actors.id = $1 AND actors.deleted_at IS NULL", id).Scan(&actor.ID, &actor.Name, &gender, &birthday, &actor.Version, &actor.LastModified, &actor.DeletedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrNotFoundInDB
 			}
 
-			curActor.Films = films
-			actors = append(actors, curActor)
+			return err
+		}
+
+		if gender {
+			actor.Gender = "female"
+		} else {
+			actor.Gender = "male"
+		}
+
+		actor.Birthday = birthday.Format(time.DateOnly)
+
+		actors := []domain.OutputActor{actor}
+		err = r.fillFilms(ctx, c, actors)
+		if err != nil {
+			return err
 		}
 
+		actor = actors[0]
+
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("repository.ReadActors(): %w", err)
+		return domain.OutputActor{}, fmt.Errorf("repository.ReadActor(): %w", err)
+	}
+
+	return actor, nil
+}
+
+func touchActorFilms(ctx context.Context, tx pgx.Tx, id int) error {
+	_, err := tx.Exec(ctx, "UPDATE films SET updated_at = now() WHERE id IN (SELECT film_id FROM film_actor WHERE actor_id = $1 UNION SELECT film_id FROM film_crew WHERE person_id = $1)", id)
+	return err
+}
+
+const actorLastModified = "GREATEST(actors.updated_at, " +
+	"(SELECT MAX(GREATEST(film_actor.updated_at, films.updated_at)) FROM film_actor JOIN films ON films.id = film_actor.film_id WHERE film_actor.actor_id = actors.id AND films.deleted_at IS NULL))"
+
+const actorColumns = "actors.id, actors.name, actors.gender, actors.birthday, actors.version, " + actorLastModified + ", actors.deleted_at"
+
+var actorSortKeys = map[string]sortKey{
+	"name":       {expr: "actors.name", cast: "text"},
+	"birthday":   {expr: "actors.birthday", cast: "timestamptz"},
+	"film_count": {expr: "(SELECT COUNT(*) FROM film_actor JOIN films ON films.id = film_actor.film_id WHERE film_actor.actor_id = actors.id AND films.deleted_at IS NULL)", cast: "bigint"},
+}
+
+func (r *actor) ReadActors(ctx context.Context, field string, order string, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors := make([]domain.OutputActor, 0)
+	var pageInfo domain.PageInfo
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		key, ok := actorSortKeys[field]
+		if !ok {
+			return appErrors.ErrUnknownSortField
+		}
+
+		key.desc = order == "desc"
+		ks := keyset{keys: []sortKey{key}, id: "actors.id"}
+
+		var args []any
+		conditions := []string{"actors.deleted_at IS NULL"}
+
+		var err error
+		pageInfo.Total, err = countRows(ctx, c, "actors", conditions, args)
+		if err != nil {
+			return err
+		}
+
+		limitClause, err := ks.paginate(pagination, &conditions, &args)
+		if err != nil {
+			return err
+		}
+
+		sqlStr := fmt.Sprintf("SELECT %s, %s FROM actors WHERE %s ORDER BY %s%s", actorColumns, ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+		rows, err := c.Query(ctx, sqlStr, args...)
+		if err != nil {
+			return err
+		}
+
+		var sortValues [][]string
+		actors, sortValues, err = scanActors(rows, false)
+		if err != nil {
+			return err
+		}
+
+		if len(actors) > pagination.Limit {
+			actors = actors[:pagination.Limit]
+			pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], actors[pagination.Limit-1].ID)
+		}
+
+		return r.fillFilms(ctx, c, actors)
+	})
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadActors(): %w", err)
+	}
+
+	return actors, pageInfo, nil
+}
+
+func (r *actor) FindActors(ctx context.Context, search domain.ActorSearch, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors := make([]domain.OutputActor, 0)
+	var (
+		pageInfo domain.PageInfo
+		err      error
+	)
+
+	if search.Fuzzy {
+		err = r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			err := setSimilarityThreshold(ctx, tx, search.Threshold)
+			if err != nil {
+				return err
+			}
+
+			actors, pageInfo, err = r.findActors(ctx, tx, search, pagination)
+			return err
+		})
+	} else {
+		err = r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+			actors, pageInfo, err = r.findActors(ctx, c, search, pagination)
+			return err
+		})
+	}
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.FindActors(): %w", err)
+	}
+
+	return actors, pageInfo, nil
+}
+
+func (r *actor) findActors(ctx context.Context, q querier, search domain.ActorSearch, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	var args []any
+	conditions := []string{"actors.deleted_at IS NULL"}
+
+	similarity := "NULL::real"
+	ks := keyset{keys: []sortKey{{expr: "actors.name", cast: "text"}}, id: "actors.id"}
+
+	if search.Name != "" {
+		args = append(args, search.Name)
+		if search.Fuzzy {
+			conditions = append(conditions, fmt.Sprintf("$%d <%% actors.name", len(args)))
+			similarity = fmt.Sprintf("word_similarity($%d, actors.name)", len(args))
+			ks.keys = []sortKey{{expr: similarity, cast: "real", desc: true}}
+		} else {
+			conditions = append(conditions, fmt.Sprintf("actors.name ILIKE '%%' || $%d || '%%'", len(args)))
+		}
+	}
+
+	if search.Gender != nil {
+		args = append(args, *search.Gender)
+		conditions = append(conditions, fmt.Sprintf("actors.gender = $%d", len(args)))
+	}
+
+	if !search.BornFrom.IsZero() {
+		args = append(args, search.BornFrom)
+		conditions = append(conditions, fmt.Sprintf("actors.birthday >= $%d", len(args)))
+	}
+
+	if !search.BornTo.IsZero() {
+		args = append(args, search.BornTo)
+		conditions = append(conditions, fmt.Sprintf("actors.birthday <= $%d", len(args)))
+	}
+
+	if search.FilmTitle != "" {
+		args = append(args, search.FilmTitle)
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_actor JOIN films ON films.id = film_actor.film_id WHERE film_actor.actor_id = actors.id AND films.deleted_at IS NULL AND films.title ILIKE '%%' || $%d || '%%')", len(args)))
+	}
+
+	var (
+		pageInfo domain.PageInfo
+		err      error
+	)
+
+	pageInfo.Total, err = countRows(ctx, q, "actors", conditions, args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	limitClause, err := ks.paginate(pagination, &conditions, &args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	sqlStr := fmt.Sprintf("SELECT %s, %s AS similarity, %s FROM actors WHERE %s ORDER BY %s%s", actorColumns, similarity, ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+	rows, err := q.Query(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	actors, sortValues, err := scanActors(rows, true)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if len(actors) > pagination.Limit {
+		actors = actors[:pagination.Limit]
+		pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], actors[pagination.Limit-1].ID)
+	}
+
+	return actors, pageInfo, r.fillFilms(ctx, q, actors)
+}
+
+func scanActors(rows pgx.Rows, withSimilarity bool) ([]domain.OutputActor, [][]string, error) {
+	defer rows.Close()
+
+	var (
+		curActor      domain.OutputActor
+		curGender     bool
+		curBirthday   time.Time
+		curSortValues []string
+		sortValues    [][]string
+	)
+
+	actors := make([]domain.OutputActor, 0)
+	for rows.Next() {
+		dest := []any{&curActor.ID, &curActor.Name, &curGender, &curBirthday, &curActor.Version, &curActor.LastModified, &curActor.DeletedAt}
+		if withSimilarity {
+			curActor.Similarity = nil
+			dest = append(dest, &curActor.Similarity)
+		}
+
+		curSortValues = nil
+		err := rows.Scan(append(dest, &curSortValues)...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if curGender {
+			curActor.Gender = "female"
+		} else {
+			curActor.Gender = "male"
+		}
+
+		curActor.Birthday = curBirthday.Format(time.DateOnly)
+		actors = append(actors, curActor)
+		sortValues = append(sortValues, curSortValues)
+	}
+
+	return actors, sortValues, rows.Err()
+}
+
+func (r *actor) fillFilms(ctx context.Context, c querier, actors []domain.OutputActor) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	actorIDs := make([]int, 0, len(actors))
+	actorIdx := make(map[int]int, len(actors))
+	for i := range actors {
+		actors[i].Films = make([]domain.ActorOutputFilm, 0)
+		actorIDs = append(actorIDs, actors[i].ID)
+		actorIdx[actors[i].ID] = i
+	}
+
+	rows, err := c.Query(ctx, "SELECT film_actor.actor_id, films.id, films.title, COALESCE(films.description, ''), films.release_date, films.rating, COALESCE(film_actor.character_name, ''), film_actor.billing, COALESCE(film_actor.role, '') FROM film_actor JOIN films ON films.id = film_actor.film_id WHERE film_actor.actor_id = ANY($1) AND films.deleted_at IS NULL ORDER BY film_actor.actor_id ASC, film_actor.billing ASC, films.id ASC", actorIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		curActorID     int
+		curFilm        domain.ActorOutputFilm
+		curReleaseDate time.Time
+	)
+
+	for rows.Next() {
+		err = rows.Scan(&curActorID, &curFilm.ID, &curFilm.Title, &curFilm.Description, &curReleaseDate, &curFilm.Rating, &curFilm.Character, &curFilm.Billing, &curFilm.Role)
+		if err != nil {
+			return err
+		}
+
+		curFilm.ReleaseDate = curReleaseDate.Format(time.DateOnly)
+
+		i := actorIdx[curActorID]
+		actors[i].Films = append(actors[i].Films, curFilm)
 	}
 
-	return actors, nil
+	return rows.Err()
 }
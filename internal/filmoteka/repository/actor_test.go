@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+func TestReadActorOrdersFilmsByBilling(t *testing.T) {
+	pg, _ := testPostgres(t)
+	ar := NewActor(pg)
+	fr := NewFilm(pg)
+
+	ctx := context.Background()
+	name := fmt.Sprintf("billed actor %d", time.Now().UnixNano())
+
+	actorID, err := ar.CreateActor(ctx, name, false, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	// the film created first has the lower id but the actor is billed lower in it
+	supportingID, err := fr.CreateFilm(ctx, name+" supporting", "", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5, []domain.CastMember{{ActorID: actorID, Billing: 3, Role: domain.RoleSupporting}}, nil)
+	require.NoError(t, err)
+
+	leadID, err := fr.CreateFilm(ctx, name+" lead", "", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5, []domain.CastMember{{ActorID: actorID, Billing: 1, Role: domain.RoleLead}}, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pg.Exec(ctx, "DELETE FROM films WHERE id = ANY($1)", []int{supportingID, leadID})
+		_, _ = pg.Exec(ctx, "DELETE FROM actors WHERE id = $1", actorID)
+	})
+
+	actor, err := ar.ReadActor(ctx, actorID)
+	require.NoError(t, err)
+	require.Len(t, actor.Films, 2)
+	require.Equal(t, leadID, actor.Films[0].ID)
+	require.Equal(t, supportingID, actor.Films[1].ID)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+const filmSnapshot = "SELECT jsonb_build_object(" +
+	"'id', films.id, 'title', films.title, 'description', films.description, " +
+	"'releaseDate', to_char(films.release_date, 'YYYY-MM-DD'), 'rating', films.rating, 'version', films.version, 'deletedAt', films.deleted_at, " +
+	"'cast', COALESCE((SELECT jsonb_agg(jsonb_build_object('actorID', actor_id, 'character', character_name, 'billing', billing, 'role', role) ORDER BY billing, actor_id) FROM film_actor WHERE film_id = films.id), '[]'::jsonb), " +
+	"'crew', COALESCE((SELECT jsonb_agg(jsonb_build_object('personID', person_id, 'job', job) ORDER BY job, person_id) FROM film_crew WHERE film_id = films.id), '[]'::jsonb), " +
+	"'genres', COALESCE((SELECT jsonb_agg(genre_id ORDER BY genre_id) FROM film_genre WHERE film_id = films.id), '[]'::jsonb)) " +
+	"FROM films WHERE films.id = $1 FOR UPDATE OF films"
+
+const actorSnapshot = "SELECT jsonb_build_object(" +
+	"'id', actors.id, 'name', actors.name, 'gender', CASE WHEN actors.gender THEN 'female' ELSE 'male' END, " +
+	"'birthday', to_char(actors.birthday, 'YYYY-MM-DD'), 'version', actors.version, 'deletedAt', actors.deleted_at) " +
+	"FROM actors WHERE actors.id = $1 FOR UPDATE OF actors"
+
+type filmState struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	ReleaseDate string              `json:"releaseDate"`
+	Rating      float32             `json:"rating"`
+	Cast        []domain.CastMember `json:"cast"`
+	Crew        []domain.CrewCredit `json:"crew"`
+	Genres      []int               `json:"genres"`
+}
+
+type actorState struct {
+	Name     string `json:"name"`
+	Gender   string `json:"gender"`
+	Birthday string `json:"birthday"`
+}
+
+var snapshots = map[string]string{
+	domain.AuditEntityFilm:  filmSnapshot,
+	domain.AuditEntityActor: actorSnapshot,
+}
+
+// snapshot locks the entity until the end of the transaction.
+func snapshot(ctx context.Context, tx pgx.Tx, entity string, id int) ([]byte, error) {
+	var state []byte
+	err := tx.QueryRow(ctx, snapshots[entity], id).Scan(&state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// writeAudit takes the state after the change itself, so it has to be called after the change in the same transaction.
+func writeAudit(ctx context.Context, tx pgx.Tx, entity string, id int, action string, before []byte) error {
+	after, err := snapshot(ctx, tx, entity, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO audit_log(changed_by, entity, entity_id, action, before, after) VALUES($1, $2, $3, $4, $5, $6)",
+		domain.LoginFromContext(ctx), entity, id, action, before, after)
+	return err
+}
+
+func readRevision(ctx context.Context, tx pgx.Tx, entity string, id int, revision int, state any) error {
+	var after []byte
+	err := tx.QueryRow(ctx, "SELECT after FROM audit_log WHERE id = $1 AND entity = $2 AND entity_id = $3", revision, entity, id).Scan(&after)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrRevisionNotFound
+		}
+
+		return err
+	}
+
+	if after == nil {
+		return appErrors.ErrRevisionNotFound
+	}
+
+	return json.Unmarshal(after, state)
+}
+
+func readHistory(ctx context.Context, q querier, entity string, id int, pagination domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	var pageInfo domain.PageInfo
+
+	ks := keyset{keys: []sortKey{{expr: "audit_log.changed_at", cast: "timestamptz", desc: true}}, id: "audit_log.id"}
+
+	args := []any{entity, id}
+	conditions := []string{"audit_log.entity = $1", "audit_log.entity_id = $2"}
+
+	var err error
+	pageInfo.Total, err = countRows(ctx, q, "audit_log", conditions, args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	limitClause, err := ks.paginate(pagination, &conditions, &args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	sqlStr := fmt.Sprintf("SELECT audit_log.id, audit_log.changed_by, audit_log.changed_at, audit_log.entity, audit_log.entity_id, audit_log.action, audit_log.before, audit_log.after, %s FROM audit_log WHERE %s ORDER BY %s%s",
+		ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+	rows, err := q.Query(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	defer rows.Close()
+
+	records := make([]domain.AuditRecord, 0)
+	var sortValues [][]string
+	for rows.Next() {
+		var (
+			record domain.AuditRecord
+			values []string
+		)
+
+		err = rows.Scan(&record.ID, &record.Login, &record.ChangedAt, &record.Entity, &record.EntityID, &record.Action, &record.Before, &record.After, &values)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		records = append(records, record)
+		sortValues = append(sortValues, values)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if len(records) > pagination.Limit {
+		records = records[:pagination.Limit]
+		pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], records[pagination.Limit-1].ID)
+	}
+
+	return records, pageInfo, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
@@ -28,7 +30,7 @@ func NewAuthorization(pg *postgres) *autorization {
 
 func (r *autorization) Register(ctx context.Context, login string, passwordHash string) error {
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		_, err := tx.Exec(ctx, "INSERT INTO auth(login, hash, is_admin) VALUES($1, $2, $3)", login, passwordHash, false)
+		_, err := tx.Exec(ctx, "INSERT INTO auth(login, hash) VALUES($1, $2)", login, passwordHash)
 		if err != nil {
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
@@ -70,10 +72,187 @@ func (r *autorization) GetPasswordHash(ctx context.Context, login string) (strin
 	return hash, nil
 }
 
-func (r *autorization) IsAdmin(ctx context.Context, login string) (bool, error) {
-	var isAdmin bool
+const rolePermissions = "COALESCE(array_agg(role_permissions.permission ORDER BY role_permissions.permission) FILTER (WHERE role_permissions.permission IS NOT NULL), '{}')"
+
+func (r *autorization) GetRole(ctx context.Context, login string) (domain.Role, error) {
+	var role domain.Role
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		err := c.QueryRow(ctx, "SELECT auth.role, "+rolePermissions+" FROM auth LEFT JOIN role_permissions ON role_permissions.role = auth.role WHERE auth.login = $1 GROUP BY auth.role", login).Scan(&role.Name, &role.Permissions)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrUserNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return domain.Role{}, fmt.Errorf("repository.GetRole(): %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *autorization) ReadRoles(ctx context.Context) ([]domain.Role, error) {
+	roles := make([]domain.Role, 0)
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		rows, err := c.Query(ctx, "SELECT roles.name, "+rolePermissions+" FROM roles LEFT JOIN role_permissions ON role_permissions.role = roles.name GROUP BY roles.name ORDER BY roles.name")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var role domain.Role
+			err = rows.Scan(&role.Name, &role.Permissions)
+			if err != nil {
+				return err
+			}
+
+			roles = append(roles, role)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.ReadRoles(): %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *autorization) CreateRefreshToken(ctx context.Context, login string, tokenHash string, expiresAt time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "DELETE FROM refresh_tokens WHERE login = $1 AND expires_at < now()", login)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO refresh_tokens(token_hash, login, expires_at) VALUES($1, $2, $3)", tokenHash, login, expiresAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return appErrors.ErrUserNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.CreateRefreshToken(): %w", err)
+	}
+
+	return nil
+}
+
+// A revoked token being used again means it was stolen, so all the refresh tokens of the user are revoked then.
+func (r *autorization) RotateRefreshToken(ctx context.Context, tokenHash string, newTokenHash string, expiresAt time.Time) (string, error) {
+	var (
+		login  string
+		reused bool
+	)
+
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var (
+			expiresAtInDB time.Time
+			revokedAt     *time.Time
+		)
+
+		err := tx.QueryRow(ctx, "SELECT login, expires_at, revoked_at FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE", tokenHash).Scan(&login, &expiresAtInDB, &revokedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrRefreshTokenIsInvalid
+			}
+
+			return err
+		}
+
+		if revokedAt != nil {
+			// the revocation has to be committed, so the error is returned after the transaction
+			reused = true
+			_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE login = $1 AND revoked_at IS NULL", login)
+			return err
+		}
+
+		if !expiresAtInDB.After(time.Now()) {
+			return appErrors.ErrRefreshTokenIsInvalid
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE token_hash = $1", tokenHash)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO refresh_tokens(token_hash, login, expires_at) VALUES($1, $2, $3)", newTokenHash, login, expiresAt)
+		return err
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("repository.RotateRefreshToken(): %w", err)
+	}
+
+	if reused {
+		return "", fmt.Errorf("repository.RotateRefreshToken(): %w", appErrors.ErrRefreshTokenIsInvalid)
+	}
+
+	return login, nil
+}
+
+func (r *autorization) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE token_hash = $1 AND revoked_at IS NULL", tokenHash)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.RevokeRefreshToken(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "DELETE FROM revoked_tokens WHERE expires_at < now()")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO revoked_tokens(token_id, expires_at) VALUES($1, $2) ON CONFLICT DO NOTHING", tokenID, expiresAt)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.RevokeToken(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var isRevoked bool
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		return c.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE token_id = $1)", tokenID).Scan(&isRevoked)
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("repository.IsTokenRevoked(): %w", err)
+	}
+
+	return isRevoked, nil
+}
+
+func (r *autorization) GetAccount(ctx context.Context, login string) (domain.Account, error) {
+	var account domain.Account
 	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
-		err := c.QueryRow(ctx, "SELECT is_admin FROM auth WHERE login = $1", login).Scan(&isAdmin)
+		err := c.QueryRow(ctx, "SELECT disabled_at IS NOT NULL, sessions_revoked_at FROM auth WHERE login = $1", login).Scan(&account.IsDisabled, &account.SessionsRevokedAt)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return appErrors.ErrUserNotFound
@@ -86,8 +265,440 @@ func (r *autorization) IsAdmin(ctx context.Context, login string) (bool, error)
 	})
 
 	if err != nil {
-		return false, fmt.Errorf("repository.IsAdmin(): %w", err)
+		return domain.Account{}, fmt.Errorf("repository.GetAccount(): %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *autorization) SetPassword(ctx context.Context, login string, passwordHash string, changedAt time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return setPassword(ctx, tx, login, passwordHash, changedAt)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.SetPassword(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) CreatePasswordResetToken(ctx context.Context, login string, tokenHash string, expiresAt time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// only the latest reset token of the user can be used
+		_, err := tx.Exec(ctx, "DELETE FROM password_reset_tokens WHERE login = $1", login)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO password_reset_tokens(token_hash, login, expires_at) VALUES($1, $2, $3)", tokenHash, login, expiresAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return appErrors.ErrUserNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.CreatePasswordResetToken(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) ResetPassword(ctx context.Context, tokenHash string, passwordHash string, changedAt time.Time) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var login string
+		err := tx.QueryRow(ctx, "UPDATE password_reset_tokens SET used_at = now() WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() RETURNING login", tokenHash).Scan(&login)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrResetTokenIsInvalid
+			}
+
+			return err
+		}
+
+		return setPassword(ctx, tx, login, passwordHash, changedAt)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.ResetPassword(): %w", err)
+	}
+
+	return nil
+}
+
+func setPassword(ctx context.Context, tx pgx.Tx, login string, passwordHash string, changedAt time.Time) error {
+	tag, err := tx.Exec(ctx, "UPDATE auth SET hash = $2, sessions_revoked_at = $3 WHERE login = $1", login, passwordHash, changedAt)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrUserNotFound
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE login = $1 AND revoked_at IS NULL", login)
+	return err
+}
+
+func (r *autorization) ReadUsers(ctx context.Context, pagination domain.Pagination) ([]domain.User, domain.PageInfo, error) {
+	users := make([]domain.User, 0)
+	var pageInfo domain.PageInfo
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		ks := keyset{keys: []sortKey{{expr: "auth.login", cast: "text"}}, id: "auth.id"}
+
+		var (
+			args       []any
+			conditions []string
+		)
+
+		var err error
+		pageInfo.Total, err = countRows(ctx, c, "auth", conditions, args)
+		if err != nil {
+			return err
+		}
+
+		limitClause, err := ks.paginate(pagination, &conditions, &args)
+		if err != nil {
+			return err
+		}
+
+		whereClause := ""
+		if len(conditions) != 0 {
+			whereClause = " WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		sqlStr := fmt.Sprintf("SELECT auth.id, auth.login, auth.role, auth.disabled_at, %s FROM auth%s ORDER BY %s%s", ks.sortValues(), whereClause, ks.orderBy(), limitClause)
+
+		rows, err := c.Query(ctx, sqlStr, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var sortValues [][]string
+		for rows.Next() {
+			var (
+				user   domain.User
+				values []string
+			)
+
+			err = rows.Scan(&user.ID, &user.Login, &user.Role, &user.DisabledAt, &values)
+			if err != nil {
+				return err
+			}
+
+			users = append(users, user)
+			sortValues = append(sortValues, values)
+		}
+
+		err = rows.Err()
+		if err != nil {
+			return err
+		}
+
+		if len(users) > pagination.Limit {
+			users = users[:pagination.Limit]
+			pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], users[pagination.Limit-1].ID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadUsers(): %w", err)
+	}
+
+	return users, pageInfo, nil
+}
+
+func (r *autorization) SetRole(ctx context.Context, login string, role string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE auth SET role = $2, sessions_revoked_at = now() WHERE login = $1", login, role)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return appErrors.ErrRoleDoesNotExist
+			}
+
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrUserNotFound
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE login = $1 AND revoked_at IS NULL", login)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.SetRole(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) SetDisabled(ctx context.Context, login string, isDisabled bool) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE auth SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, now()) END WHERE login = $1", login, isDisabled)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrUserNotFound
+		}
+
+		if !isDisabled {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET revoked_at = now() WHERE login = $1 AND revoked_at IS NULL", login)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.SetDisabled(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) DeleteUser(ctx context.Context, login string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM auth WHERE login = $1", login)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrUserNotFound
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.DeleteUser(): %w", err)
+	}
+
+	return nil
+}
+
+// The rows are locked until the attempt is counted, so parallel attempts are counted one after another.
+func (r *autorization) ReserveLoginAttempt(ctx context.Context, login string, ip string, policy domain.LoginThrottlePolicy, attemptAt time.Time) (time.Duration, error) {
+	resetBefore := attemptAt.Add(-policy.LockoutDuration)
+
+	var wait time.Duration
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		keys := []domain.LoginFailures{{Kind: domain.LockoutKindLogin, Subject: login}, {Kind: domain.LockoutKindIP, Subject: ip}}
+		for i := range keys {
+			// the update does not change anything, it only locks the row, and the insert locks a new one
+			err := tx.QueryRow(ctx, "INSERT INTO login_failures(kind, subject, failures, last_failure_at) VALUES($1, $2, 0, $3) "+
+				"ON CONFLICT (kind, subject) DO UPDATE SET failures = login_failures.failures RETURNING failures, last_failure_at",
+				keys[i].Kind, keys[i].Subject, attemptAt).Scan(&keys[i].Failures, &keys[i].LastFailureAt)
+			if err != nil {
+				return err
+			}
+
+			wait = max(wait, policy.RetryAfter(keys[i], attemptAt))
+		}
+
+		if wait > 0 {
+			return nil
+		}
+
+		for _, key := range keys {
+			_, err := tx.Exec(ctx, "UPDATE login_failures SET failures = CASE WHEN last_failure_at < $3 THEN 1 ELSE failures + 1 END, last_failure_at = $4 "+
+				"WHERE kind = $1 AND subject = $2", key.Kind, key.Subject, resetBefore, attemptAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.ReserveLoginAttempt(): %w", err)
+	}
+
+	// stale failures are deleted outside of the transaction, so it does not wait for the rows locked by other attempts
+	err = r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		_, err := c.Exec(ctx, "DELETE FROM login_failures WHERE last_failure_at < $1", resetBefore)
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.ReserveLoginAttempt(): %w", err)
+	}
+
+	return wait, nil
+}
+
+func (r *autorization) ReleaseLoginAttempt(ctx context.Context, login string, ip string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "DELETE FROM login_failures WHERE kind = $1 AND subject = $2", domain.LockoutKindLogin, login)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE login_failures SET failures = failures - 1 WHERE kind = $1 AND subject = $2 AND failures > 0", domain.LockoutKindIP, ip)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.ReleaseLoginAttempt(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) ResetLoginFailures(ctx context.Context, login string) error {
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		_, err := c.Exec(ctx, "DELETE FROM login_failures WHERE kind = $1 AND subject = $2", domain.LockoutKindLogin, login)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.ResetLoginFailures(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) ReadLockouts(ctx context.Context, lockedSince time.Time, maxFailures int, maxFailuresPerIP int) ([]domain.LoginFailures, error) {
+	lockouts := make([]domain.LoginFailures, 0)
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		rows, err := c.Query(ctx, "SELECT kind, subject, failures, last_failure_at FROM login_failures WHERE last_failure_at >= $1 AND "+
+			"((kind = $2 AND $3 > 0 AND failures >= $3) OR (kind = $4 AND $5 > 0 AND failures >= $5)) ORDER BY last_failure_at DESC, kind, subject",
+			lockedSince, domain.LockoutKindLogin, maxFailures, domain.LockoutKindIP, maxFailuresPerIP)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var lockout domain.LoginFailures
+			err = rows.Scan(&lockout.Kind, &lockout.Subject, &lockout.Failures, &lockout.LastFailureAt)
+			if err != nil {
+				return err
+			}
+
+			lockouts = append(lockouts, lockout)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.ReadLockouts(): %w", err)
+	}
+
+	return lockouts, nil
+}
+
+func (r *autorization) CreateAPIKey(ctx context.Context, name string, scope string, keyHash string) (domain.APIKey, error) {
+	apiKey := domain.APIKey{Name: name, Scope: scope, CreatedBy: domain.LoginFromContext(ctx)}
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		err := c.QueryRow(ctx, "INSERT INTO api_keys(name, key_hash, scope, created_by) VALUES($1, $2, $3, $4) RETURNING id, created_at",
+			name, keyHash, scope, apiKey.CreatedBy).Scan(&apiKey.ID, &apiKey.CreatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return appErrors.ErrAPIKeyAlreadyExists
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("repository.CreateAPIKey(): %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *autorization) ReadAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	apiKeys := make([]domain.APIKey, 0)
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		rows, err := c.Query(ctx, "SELECT id, name, scope, created_by, created_at, last_used_at, revoked_at FROM api_keys ORDER BY created_at DESC, id DESC")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var apiKey domain.APIKey
+			err = rows.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Scope, &apiKey.CreatedBy, &apiKey.CreatedAt, &apiKey.LastUsedAt, &apiKey.RevokedAt)
+			if err != nil {
+				return err
+			}
+
+			apiKeys = append(apiKeys, apiKey)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.ReadAPIKeys(): %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *autorization) RevokeAPIKey(ctx context.Context, id int) error {
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		tag, err := c.Exec(ctx, "UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL", id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrAPIKeyNotFound
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.RevokeAPIKey(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *autorization) CheckAPIKey(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	var apiKey domain.APIKey
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		err := c.QueryRow(ctx, "UPDATE api_keys SET last_used_at = now() WHERE key_hash = $1 AND revoked_at IS NULL "+
+			"RETURNING id, name, scope, created_by, created_at, last_used_at", keyHash).
+			Scan(&apiKey.ID, &apiKey.Name, &apiKey.Scope, &apiKey.CreatedBy, &apiKey.CreatedAt, &apiKey.LastUsedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrAPIKeyIsInvalid
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("repository.CheckAPIKey(): %w", err)
 	}
 
-	return isAdmin, nil
+	return apiKey, nil
 }
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+func TestReserveLoginAttemptConcurrently(t *testing.T) {
+	const attempts = 20
+
+	pg, _ := testPostgres(t)
+	r := NewAuthorization(pg)
+
+	ctx := context.Background()
+	login := fmt.Sprintf("concurrent-%d", time.Now().UnixNano())
+	ip := "192.0.2.1"
+	policy := domain.LoginThrottlePolicy{MaxFailures: 3, MaxFailuresPerIP: attempts * 2, LockoutDuration: time.Hour}
+
+	t.Cleanup(func() {
+		_, _ = pg.Exec(ctx, "DELETE FROM login_failures WHERE subject = ANY($1)", []string{login, ip})
+	})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+		errs    []error
+	)
+
+	// the database keeps microseconds, so the wait is computed from the same time as here
+	now := time.Now().Truncate(time.Microsecond)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			wait, err := r.ReserveLoginAttempt(ctx, login, ip, policy, now)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+			} else if wait == 0 {
+				allowed++
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Empty(t, errs)
+
+	// parallel wrong passwords get no more attempts than sequential ones
+	require.Equal(t, policy.MaxFailures, allowed)
+
+	wait, err := r.ReserveLoginAttempt(ctx, login, ip, policy, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, policy.LockoutDuration-time.Minute, wait)
+
+	err = r.ReleaseLoginAttempt(ctx, login, ip)
+	require.NoError(t, err)
+
+	wait, err = r.ReserveLoginAttempt(ctx, login, ip, policy, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestSetRoleRevokesSessions(t *testing.T) {
+	pg, _ := testPostgres(t)
+	r := NewAuthorization(pg)
+
+	ctx := context.Background()
+	login := fmt.Sprintf("demoted-%d", time.Now().UnixNano())
+
+	err := r.Register(ctx, login, "hash")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = r.DeleteUser(ctx, login)
+	})
+
+	err = r.CreateRefreshToken(ctx, login, login+"-refresh", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	account, err := r.GetAccount(ctx, login)
+	require.NoError(t, err)
+	require.Nil(t, account.SessionsRevokedAt)
+
+	err = r.SetRole(ctx, login, "editor")
+	require.NoError(t, err)
+
+	// the tokens with the permissions of the previous role are not accepted and can not be refreshed
+	account, err = r.GetAccount(ctx, login)
+	require.NoError(t, err)
+	require.NotNil(t, account.SessionsRevokedAt)
+
+	_, err = r.RotateRefreshToken(ctx, login+"-refresh", login+"-new", time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, appErrors.ErrRefreshTokenIsInvalid)
+
+	role, err := r.GetRole(ctx, login)
+	require.NoError(t, err)
+	require.Equal(t, "editor", role.Name)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+type sortKey struct {
+	expr string
+	cast string
+	desc bool
+}
+
+// The id column is always the last ascending key, so the order is deterministic and any row can be used as a cursor.
+type keyset struct {
+	keys []sortKey
+	id   string
+}
+
+type cursor struct {
+	Order  uint32   `json:"o"`
+	Values []string `json:"v"`
+	ID     int      `json:"id"`
+}
+
+func (k keyset) orderBy() string {
+	parts := make([]string, 0, len(k.keys)+1)
+	for _, key := range k.keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+
+		parts = append(parts, key.expr+" "+direction)
+	}
+
+	return strings.Join(append(parts, k.id+" ASC"), ", ")
+}
+
+func (k keyset) sortValues() string {
+	values := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		values = append(values, "("+key.expr+")::text")
+	}
+
+	return "ARRAY[" + strings.Join(values, ", ") + "]::text[]"
+}
+
+func (k keyset) signature() uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(k.orderBy()))
+	return h.Sum32()
+}
+
+func (k keyset) cursor(values []string, id int) string {
+	data, _ := json.Marshal(cursor{Order: k.signature(), Values: values, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (k keyset) after(token string, args *[]any) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", appErrors.ErrWrongCursor
+	}
+
+	var c cursor
+	err = json.Unmarshal(data, &c)
+	if err != nil || c.Order != k.signature() || len(c.Values) != len(k.keys) {
+		return "", appErrors.ErrWrongCursor
+	}
+
+	var (
+		alternatives []string
+		equalities   []string
+	)
+
+	for i, key := range k.keys {
+		*args = append(*args, c.Values[i])
+		value := fmt.Sprintf("$%d::text::%s", len(*args), key.cast)
+
+		operator := ">"
+		if key.desc {
+			operator = "<"
+		}
+
+		alternatives = append(alternatives, strings.Join(append(equalities, fmt.Sprintf("%s %s %s", key.expr, operator, value)), " AND "))
+		equalities = append(equalities, fmt.Sprintf("%s = %s", key.expr, value))
+	}
+
+	*args = append(*args, c.ID)
+	alternatives = append(alternatives, strings.Join(append(equalities, fmt.Sprintf("%s > $%d", k.id, len(*args))), " AND "))
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", nil
+}
+
+// One extra row is requested to find out if there is a next page.
+func (k keyset) paginate(pagination domain.Pagination, conditions *[]string, args *[]any) (string, error) {
+	if pagination.After != "" {
+		condition, err := k.after(pagination.After, args)
+		if err != nil {
+			return "", err
+		}
+
+		*conditions = append(*conditions, condition)
+		*args = append(*args, pagination.Limit+1)
+		return fmt.Sprintf(" LIMIT $%d", len(*args)), nil
+	}
+
+	*args = append(*args, pagination.Limit+1, (pagination.Page-1)*pagination.Limit)
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(*args)-1, len(*args)), nil
+}
+
+// countRows has to be called before the cursor condition is added.
+func countRows(ctx context.Context, q querier, from string, conditions []string, args []any) (int, error) {
+	sqlStr := "SELECT COUNT(*) FROM " + from
+	if len(conditions) != 0 {
+		sqlStr += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := q.QueryRow(ctx, sqlStr, args...).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+func TestKeyset(t *testing.T) {
+	ks := keyset{keys: []sortKey{{expr: "films.rating", cast: "real", desc: true}, {expr: "films.title", cast: "text"}}, id: "films.id"}
+
+	require.Equal(t, "films.rating DESC, films.title ASC, films.id ASC", ks.orderBy())
+	require.Equal(t, "ARRAY[(films.rating)::text, (films.title)::text]::text[]", ks.sortValues())
+
+	args := []any{"drama"}
+	condition, err := ks.after(ks.cursor([]string{"8.6", "abc"}, 5), &args)
+	require.NoError(t, err)
+	require.Equal(t, "(films.rating < $2::text::real OR films.rating = $2::text::real AND films.title > $3::text::text OR films.rating = $2::text::real AND films.title = $3::text::text AND films.id > $4)", condition)
+	require.Equal(t, []any{"drama", "8.6", "abc", 5}, args)
+
+	other := keyset{keys: []sortKey{{expr: "films.rating", cast: "real"}, {expr: "films.title", cast: "text"}}, id: "films.id"}
+	_, err = other.after(ks.cursor([]string{"8.6", "abc"}, 5), &args)
+	require.ErrorIs(t, err, appErrors.ErrWrongCursor)
+
+	_, err = ks.after("not a cursor", &args)
+	require.ErrorIs(t, err, appErrors.ErrWrongCursor)
+
+	var conditions []string
+	args = nil
+	limitClause, err := ks.paginate(domain.Pagination{Page: 3, Limit: 10}, &conditions, &args)
+	require.NoError(t, err)
+	require.Equal(t, " LIMIT $1 OFFSET $2", limitClause)
+	require.Equal(t, []any{11, 20}, args)
+	require.Empty(t, conditions)
+
+	args = nil
+	limitClause, err = ks.paginate(domain.Pagination{Page: 1, Limit: 10, After: ks.cursor([]string{"8.6", "abc"}, 5)}, &conditions, &args)
+	require.NoError(t, err)
+	require.Equal(t, " LIMIT $4", limitClause)
+	require.Len(t, conditions, 1)
+}
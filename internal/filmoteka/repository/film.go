@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgerrcode"
@@ -27,37 +28,25 @@ func NewFilm(pg *postgres) *film {
 	return &film{db: pg}
 }
 
-func (r *film) CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, actors []int) (int, error) {
+func (r *film) CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, cast []domain.CastMember, genres []int) (int, error) {
 	var id int
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		err := tx.QueryRow(ctx, "INSERT INTO films(title, description, release_date, rating) VALUES($1, $2, $3, $4) RETURNING id", title, description, releaseDate, rating).Scan(&id)
+		err := tx.QueryRow(ctx, "INSERT INTO films(title, description, release_date, rating) VALUES($1, NULLIF($2, ''), $3, $4) RETURNING id", title, description, releaseDate, rating).Scan(&id)
 		if err != nil {
 			return err
 		}
 
-		for _, actorID := range actors {
-			_, err = tx.Exec(ctx, "INSERT INTO film_actor(actor_id, film_id) VALUES($1, $2)", actorID, id)
-			if err != nil {
-				break
-			}
+		err = insertCast(ctx, tx, id, cast)
+		if err != nil {
+			return err
 		}
 
+		err = insertFilmGenres(ctx, tx, id, genres)
 		if err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) {
-				if pgErr.Code == "P0001" {
-					return appErrors.ErrActorNotBornBeforeFilmRelease
-				}
-
-				if pgErr.Code == pgerrcode.ForeignKeyViolation {
-					return appErrors.ErrActorDoesNotExist
-				}
-			}
-
 			return err
 		}
 
-		return nil
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionCreate, nil)
 	})
 
 	if err != nil {
@@ -67,17 +56,39 @@ func (r *film) CreateFilm(ctx context.Context, title string, description string,
 	return id, nil
 }
 
-func (r *film) UpdateFilm(ctx context.Context, id int, title string, description string, releaseDate time.Time, rating *float32, actors []int) error {
-	var (
-		titleInDB       string
-		descriptionInDB string
-		releaseDateInDB time.Time
-		ratingInDB      float32
-		actorsInDB      []int
-	)
+func (r *film) UpdateFilm(ctx context.Context, id int, version int, title string, description string, releaseDate time.Time, rating float32, cast []domain.CastMember, genres []int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
+		if err != nil {
+			return err
+		}
+
+		err = updateFilm(ctx, tx, id, version, title, description, releaseDate, rating, cast, genres)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionUpdate, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.UpdateFilm(): %w", err)
+	}
 
+	return nil
+}
+
+func (r *film) PatchFilm(ctx context.Context, id int, version int, patch domain.FilmPatch) error {
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		err := tx.QueryRow(ctx, "SELECT title, description, release_date, rating FROM films WHERE id = $1", id).Scan(&titleInDB, &descriptionInDB, &releaseDateInDB, &ratingInDB)
+		var (
+			title       string
+			description string
+			releaseDate time.Time
+			rating      float32
+			versionInDB int
+		)
+
+		err := tx.QueryRow(ctx, "SELECT title, COALESCE(description, ''), release_date, rating, version FROM films WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&title, &description, &releaseDate, &rating, &versionInDB)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return appErrors.ErrNotFoundInDB
@@ -86,96 +97,226 @@ func (r *film) UpdateFilm(ctx context.Context, id int, title string, description
 			return err
 		}
 
-		if actors == nil {
-			rows, err := tx.Query(ctx, "SELECT actor_id FROM film_actor WHERE film_id = $1", id)
-			if err != nil {
-				return err
-			}
+		if version != 0 && version != versionInDB {
+			return appErrors.ErrVersionMismatch
+		}
 
-			var actorID int
-			for rows.Next() {
-				err = rows.Scan(&actorID)
-				if err != nil {
-					return err
-				}
+		if patch.Title != nil {
+			title = *patch.Title
+		}
 
-				actorsInDB = append(actorsInDB, actorID)
-			}
+		if patch.Description != nil {
+			description = *patch.Description
+		}
 
-			actors = actorsInDB
+		if patch.ReleaseDate != nil {
+			releaseDate = *patch.ReleaseDate
 		}
 
-		if title == "" {
-			title = titleInDB
+		if patch.Rating != nil {
+			rating = *patch.Rating
 		}
 
-		if description == "" {
-			description = descriptionInDB
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
+		if err != nil {
+			return err
 		}
 
-		var defaultTime time.Time
-		if releaseDate == defaultTime {
-			releaseDate = releaseDateInDB
+		err = updateFilm(ctx, tx, id, versionInDB, title, description, releaseDate, rating, patch.Cast, patch.Genres)
+		if err != nil {
+			return err
 		}
 
-		if rating == nil {
-			rating = &ratingInDB
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionUpdate, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.PatchFilm(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *film) RevertFilm(ctx context.Context, id int, version int, revision int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var state filmState
+		err := readRevision(ctx, tx, domain.AuditEntityFilm, id, revision, &state)
+		if err != nil {
+			return err
 		}
 
-		tag, err := tx.Exec(ctx, "UPDATE films SET title = $1, description = $2, release_date = $3, rating = $4 WHERE id = $5", title, description, releaseDate, *rating, id)
+		releaseDate, err := time.Parse(time.DateOnly, state.ReleaseDate)
 		if err != nil {
 			return err
 		}
 
-		if tag.RowsAffected() == 0 {
-			return appErrors.ErrNotFoundInDB
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
+		if err != nil {
+			return err
 		}
 
-		_, err = tx.Exec(ctx, "DELETE FROM film_actor WHERE film_id = $1", id)
+		// links to deleted actors are kept as they are, so they are left out of the reverted cast and crew
+		hiddenCast, hiddenCrew, err := selectHiddenLinks(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		for _, actorID := range actors {
-			_, err = tx.Exec(ctx, "INSERT INTO film_actor(actor_id, film_id) VALUES($1, $2)", actorID, id)
-			if err != nil {
-				var pgErr *pgconn.PgError
-				if errors.As(err, &pgErr) {
-					if pgErr.Code == "P0001" {
-						return appErrors.ErrActorNotBornBeforeFilmRelease
-					}
-
-					if pgErr.Code == pgerrcode.ForeignKeyViolation {
-						return appErrors.ErrActorDoesNotExist
-					}
-				}
+		cast := make([]domain.CastMember, 0, len(state.Cast))
+		for _, castMember := range state.Cast {
+			if !hiddenCast[castMember.ActorID] {
+				cast = append(cast, castMember)
+			}
+		}
 
-				return err
+		genres := state.Genres
+		if genres == nil {
+			genres = make([]int, 0)
+		}
+
+		err = updateFilm(ctx, tx, id, version, state.Title, state.Description, releaseDate, state.Rating, cast, genres)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM film_crew WHERE film_id = $1 AND person_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)", id)
+		if err != nil {
+			return err
+		}
+
+		crew := make([]domain.CrewCredit, 0, len(state.Crew))
+		for _, credit := range state.Crew {
+			if !hiddenCrew[credit] {
+				crew = append(crew, credit)
 			}
 		}
 
-		return nil
+		err = insertCrew(ctx, tx, id, crew)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionRevert, before)
 	})
 
 	if err != nil {
-		return fmt.Errorf("repository.UpdateFilm(): %w", err)
+		return fmt.Errorf("repository.RevertFilm(): %w", err)
 	}
 
 	return nil
 }
 
-func (r *film) DeleteFilm(ctx context.Context, id int) error {
+func selectHiddenLinks(ctx context.Context, tx pgx.Tx, filmID int) (map[int]bool, map[domain.CrewCredit]bool, error) {
+	rows, err := tx.Query(ctx, "SELECT film_actor.actor_id FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = $1 AND actors.deleted_at IS NOT NULL", filmID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hiddenCast := make(map[int]bool)
+	for rows.Next() {
+		var actorID int
+		err = rows.Scan(&actorID)
+		if err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+
+		hiddenCast[actorID] = true
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = tx.Query(ctx, "SELECT film_crew.person_id, film_crew.job FROM film_crew JOIN actors ON actors.id = film_crew.person_id WHERE film_crew.film_id = $1 AND actors.deleted_at IS NOT NULL", filmID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	hiddenCrew := make(map[domain.CrewCredit]bool)
+	for rows.Next() {
+		var credit domain.CrewCredit
+		err = rows.Scan(&credit.PersonID, &credit.Job)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hiddenCrew[credit] = true
+	}
+
+	return hiddenCast, hiddenCrew, rows.Err()
+}
+
+// nil cast is reinserted as is, so the release date is checked against birthdays of the actors, nil genres are left as they are.
+func updateFilm(ctx context.Context, tx pgx.Tx, id int, version int, title string, description string, releaseDate time.Time, rating float32, cast []domain.CastMember, genres []int) error {
+	tag, err := tx.Exec(ctx, "UPDATE films SET title = $1, description = NULLIF($2, ''), release_date = $3, rating = $4, version = version + 1 WHERE id = $5 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)", title, description, releaseDate, rating, id, version)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return versionMismatchOrNotFound(ctx, tx, "films", id)
+	}
+
+	if cast == nil {
+		cast, err = selectCast(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// actors of the current cast are touched, so the ones removed from it are seen as modified
+	err = touchFilmActors(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	// links to deleted actors are kept, so they are back in the cast if the actors are restored
+	_, err = tx.Exec(ctx, "DELETE FROM film_actor WHERE film_id = $1 AND actor_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)", id)
+	if err != nil {
+		return err
+	}
+
+	err = insertCast(ctx, tx, id, cast)
+	if err != nil {
+		return err
+	}
+
+	if genres == nil {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM film_genre WHERE film_id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return insertFilmGenres(ctx, tx, id, genres)
+}
+
+func (r *film) DeleteFilm(ctx context.Context, id int, version int) error {
 	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		tag, err := tx.Exec(ctx, "DELETE FROM films WHERE id = $1", id)
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE films SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)", id, version)
 		if err != nil {
 			return err
 		}
 
 		if tag.RowsAffected() == 0 {
-			return appErrors.ErrNotFoundInDB
+			return versionMismatchOrNotFound(ctx, tx, "films", id)
 		}
 
-		return nil
+		err = touchFilmActors(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionDelete, before)
 	})
 
 	if err != nil {
@@ -185,151 +326,771 @@ func (r *film) DeleteFilm(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *film) ReadFilms(ctx context.Context, field string, order string, page int, limit int) ([]domain.OutputFilm, error) {
-	var films []domain.OutputFilm
-	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
-		sqlStr := fmt.Sprintf("SELECT id, title, description, release_date, rating FROM films ORDER BY %s %s", field, order)
-		rows, err := c.Query(ctx, sqlStr+" LIMIT $1 OFFSET $2", limit, (page-1)*limit)
+func (r *film) RestoreFilm(ctx context.Context, id int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
 		if err != nil {
 			return err
 		}
 
-		var curFilm domain.OutputFilm
-		var curReleaseDate time.Time
-		for rows.Next() {
-			err = rows.Scan(&curFilm.ID, &curFilm.Title, &curFilm.Description, &curReleaseDate, &curFilm.Rating)
-			if err != nil {
-				return err
-			}
+		tag, err := tx.Exec(ctx, "UPDATE films SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
 
-			curFilm.ReleaseDate = curReleaseDate.Format(time.DateOnly)
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
 
-			actorRows, err := r.db.Query(ctx, "SELECT actor_id FROM film_actor WHERE film_id = $1 ORDER BY actor_id ASC", curFilm.ID)
-			if err != nil {
-				return err
-			}
+		err = touchFilmActors(ctx, tx, id)
+		if err != nil {
+			return err
+		}
 
-			var (
-				curActor    domain.FilmOutputActor
-				curGender   bool
-				curBirthday time.Time
-			)
-
-			curFilm.Actors = make([]domain.FilmOutputActor, 0)
-			for actorRows.Next() {
-				err = actorRows.Scan(&curActor.ID)
-				if err != nil {
-					return err
-				}
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionRestore, before)
+	})
 
-				err = r.db.QueryRow(ctx, "SELECT name, gender, birthday FROM actors WHERE id = $1", curActor.ID).Scan(&curActor.Name, &curGender, &curBirthday)
-				if err != nil {
-					return err
-				}
+	if err != nil {
+		return fmt.Errorf("repository.RestoreFilm(): %w", err)
+	}
 
-				if curGender {
-					curActor.Gender = "female"
-				} else {
-					curActor.Gender = "male"
-				}
+	return nil
+}
+
+func (r *film) PurgeFilm(ctx context.Context, id int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, id)
+		if err != nil {
+			return err
+		}
 
-				curActor.Birthday = curBirthday.Format(time.DateOnly)
+		err = touchFilmActors(ctx, tx, id)
+		if err != nil {
+			return err
+		}
 
-				curFilm.Actors = append(curFilm.Actors, curActor)
-			}
+		tag, err := tx.Exec(ctx, "DELETE FROM films WHERE id = $1 AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
 
-			films = append(films, curFilm)
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
 		}
 
-		return nil
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, id, domain.AuditActionPurge, before)
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("repository.ReadFilms(): %w", err)
+		return fmt.Errorf("repository.PurgeFilm(): %w", err)
 	}
 
-	return films, nil
+	return nil
 }
 
-func (r *film) FindFilms(ctx context.Context, filmTitleFragment string, actorNameFragment string, page int, limit int) ([]domain.OutputFilm, error) {
-	var films []domain.OutputFilm
+func (r *film) ReadDeletedFilms(ctx context.Context, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	var (
+		films    []domain.OutputFilm
+		pageInfo domain.PageInfo
+	)
+
 	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
-		sqlStr := "SELECT DISTINCT films.id, films.title, films.description, films.release_date, films.rating FROM films "
-		pagination := " LIMIT "
+		ks := keyset{keys: []sortKey{{expr: "films.deleted_at", cast: "timestamptz", desc: true}}, id: "films.id"}
+
+		var args []any
+		conditions := []string{"films.deleted_at IS NOT NULL"}
 
-		var rows pgx.Rows
 		var err error
-		if filmTitleFragment != "" && actorNameFragment != "" {
-			sqlStr += "JOIN film_actor ON films.id = film_actor.film_id JOIN actors ON actors.id = film_actor.actor_id WHERE films.title ILIKE '%' || $1 || '%' AND actors.name ILIKE '%' || $2 || '%'"
-			pagination += "$3 OFFSET $4"
-			rows, err = c.Query(ctx, sqlStr+pagination, filmTitleFragment, actorNameFragment, limit, (page-1)*limit)
-		} else if actorNameFragment != "" {
-			sqlStr += "JOIN film_actor ON films.id = film_actor.film_id JOIN actors ON actors.id = film_actor.actor_id WHERE actors.name ILIKE '%' || $1 || '%'"
-			pagination += "$2 OFFSET $3"
-			rows, err = c.Query(ctx, sqlStr+pagination, actorNameFragment, limit, (page-1)*limit)
-		} else {
-			sqlStr += "WHERE title ILIKE '%' || $1 || '%'"
-			pagination += "$2 OFFSET $3"
-			rows, err = c.Query(ctx, sqlStr+pagination, filmTitleFragment, limit, (page-1)*limit)
+		pageInfo.Total, err = countRows(ctx, c, "films", conditions, args)
+		if err != nil {
+			return err
 		}
 
+		limitClause, err := ks.paginate(pagination, &conditions, &args)
 		if err != nil {
 			return err
 		}
 
-		var curFilm domain.OutputFilm
-		var curReleaseDate time.Time
-		for rows.Next() {
-			err = rows.Scan(&curFilm.ID, &curFilm.Title, &curFilm.Description, &curReleaseDate, &curFilm.Rating)
-			if err != nil {
-				return err
-			}
+		sqlStr := fmt.Sprintf("SELECT %s, %s FROM films WHERE %s ORDER BY %s%s", filmColumns, ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
 
-			curFilm.ReleaseDate = curReleaseDate.Format(time.DateOnly)
+		rows, err := c.Query(ctx, sqlStr, args...)
+		if err != nil {
+			return err
+		}
 
-			actorRows, err := r.db.Query(ctx, "SELECT actor_id FROM film_actor WHERE film_id = $1 ORDER BY actor_id ASC", curFilm.ID)
-			if err != nil {
-				return err
-			}
+		var sortValues [][]string
+		films, sortValues, err = scanFilms(rows, false)
+		if err != nil {
+			return err
+		}
 
-			var (
-				curActor    domain.FilmOutputActor
-				curGender   bool
-				curBirthday time.Time
-			)
-
-			curFilm.Actors = make([]domain.FilmOutputActor, 0)
-			for actorRows.Next() {
-				err = actorRows.Scan(&curActor.ID)
-				if err != nil {
-					return err
-				}
+		if len(films) > pagination.Limit {
+			films = films[:pagination.Limit]
+			pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], films[pagination.Limit-1].ID)
+		}
 
-				err = r.db.QueryRow(ctx, "SELECT name, gender, birthday FROM actors WHERE id = $1", curActor.ID).Scan(&curActor.Name, &curGender, &curBirthday)
-				if err != nil {
-					return err
-				}
+		return r.fillRelations(ctx, c, films)
+	})
 
-				if curGender {
-					curActor.Gender = "female"
-				} else {
-					curActor.Gender = "male"
-				}
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadDeletedFilms(): %w", err)
+	}
 
-				curActor.Birthday = curBirthday.Format(time.DateOnly)
+	return films, pageInfo, nil
+}
+
+func (r *film) ReadFilmHistory(ctx context.Context, id int, pagination domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	var (
+		records  []domain.AuditRecord
+		pageInfo domain.PageInfo
+	)
+
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		var err error
+		records, pageInfo, err = readHistory(ctx, c, domain.AuditEntityFilm, id, pagination)
+		return err
+	})
 
-				curFilm.Actors = append(curFilm.Actors, curActor)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadFilmHistory(): %w", err)
+	}
+
+	return records, pageInfo, nil
+}
+
+func (r *film) ReadFilm(ctx context.Context, id int) (domain.OutputFilm, error) {
+	var film domain.OutputFilm
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		var releaseDate time.Time
+		err := c.QueryRow(ctx, "SELECT "+filmColumns+" FROM films WHERE films.id = $1 AND films.deleted_at IS NULL", id).Scan(&film.ID, &film.Title, &film.Description, &releaseDate, &film.Rating, &film.Version, &film.LastModified, &film.DeletedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrNotFoundInDB
 			}
 
-			films = append(films, curFilm)
+			return err
+		}
+
+		film.ReleaseDate = releaseDate.Format(time.DateOnly)
+
+		films := []domain.OutputFilm{film}
+		err = r.fillRelations(ctx, c, films)
+		if err != nil {
+			return err
 		}
 
+		film = films[0]
+
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("repository.FindFilms(): %w", err)
+		return domain.OutputFilm{}, fmt.Errorf("repository.ReadFilm(): %w", err)
+	}
+
+	return film, nil
+}
+
+func touchFilmActors(ctx context.Context, tx pgx.Tx, id int) error {
+	_, err := tx.Exec(ctx, "UPDATE actors SET updated_at = now() WHERE id IN (SELECT actor_id FROM film_actor WHERE film_id = $1)", id)
+	return err
+}
+
+const filmLastModified = "GREATEST(films.updated_at, " +
+	"(SELECT MAX(GREATEST(film_actor.updated_at, actors.updated_at)) FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = films.id AND actors.deleted_at IS NULL), " +
+	"(SELECT MAX(actors.updated_at) FROM film_crew JOIN actors ON actors.id = film_crew.person_id WHERE film_crew.film_id = films.id AND actors.deleted_at IS NULL))"
+
+const filmColumns = "films.id, films.title, COALESCE(films.description, ''), films.release_date, films.rating, films.version, " + filmLastModified + ", films.deleted_at"
+
+var filmSortKeys = map[string]sortKey{
+	"title":        {expr: "films.title", cast: "text"},
+	"rating":       {expr: "films.rating", cast: "real"},
+	"release_date": {expr: "films.release_date", cast: "timestamptz"},
+}
+
+func (r *film) ReadFilms(ctx context.Context, field string, order string, filter domain.FilmFilter, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	var (
+		films    []domain.OutputFilm
+		pageInfo domain.PageInfo
+	)
+
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		key, ok := filmSortKeys[field]
+		if !ok {
+			return appErrors.ErrUnknownSortField
+		}
+
+		key.desc = order == "desc"
+		ks := keyset{keys: []sortKey{key}, id: "films.id"}
+
+		var args []any
+		conditions := []string{"films.deleted_at IS NULL"}
+
+		if filter.Genre != "" {
+			args = append(args, filter.Genre)
+			conditions = append(conditions, genreCondition(len(args)))
+		}
+
+		if !filter.ReleasedFrom.IsZero() {
+			args = append(args, filter.ReleasedFrom)
+			conditions = append(conditions, fmt.Sprintf("films.release_date >= $%d", len(args)))
+		}
+
+		if !filter.ReleasedTo.IsZero() {
+			args = append(args, filter.ReleasedTo)
+			conditions = append(conditions, fmt.Sprintf("films.release_date <= $%d", len(args)))
+		}
+
+		if filter.MinRating != nil {
+			args = append(args, *filter.MinRating)
+			conditions = append(conditions, fmt.Sprintf("films.rating >= $%d", len(args)))
+		}
+
+		if filter.MaxRating != nil {
+			args = append(args, *filter.MaxRating)
+			conditions = append(conditions, fmt.Sprintf("films.rating <= $%d", len(args)))
+		}
+
+		if filter.ActorID != 0 {
+			args = append(args, filter.ActorID)
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = films.id AND actors.deleted_at IS NULL AND film_actor.actor_id = $%d)", len(args)))
+		}
+
+		var err error
+		pageInfo.Total, err = countRows(ctx, c, "films", conditions, args)
+		if err != nil {
+			return err
+		}
+
+		limitClause, err := ks.paginate(pagination, &conditions, &args)
+		if err != nil {
+			return err
+		}
+
+		sqlStr := fmt.Sprintf("SELECT %s, %s FROM films WHERE %s ORDER BY %s%s", filmColumns, ks.sortValues(), strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+		rows, err := c.Query(ctx, sqlStr, args...)
+		if err != nil {
+			return err
+		}
+
+		var sortValues [][]string
+		films, sortValues, err = scanFilms(rows, false)
+		if err != nil {
+			return err
+		}
+
+		if len(films) > pagination.Limit {
+			films = films[:pagination.Limit]
+			pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], films[pagination.Limit-1].ID)
+		}
+
+		return r.fillRelations(ctx, c, films)
+	})
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.ReadFilms(): %w", err)
+	}
+
+	return films, pageInfo, nil
+}
+
+func (r *film) FindFilms(ctx context.Context, search domain.FilmSearch, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	var (
+		films    []domain.OutputFilm
+		pageInfo domain.PageInfo
+		err      error
+	)
+
+	if search.Fuzzy {
+		err = r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			err := setSimilarityThreshold(ctx, tx, search.Threshold)
+			if err != nil {
+				return err
+			}
+
+			films, pageInfo, err = r.findFilms(ctx, tx, search, pagination)
+			return err
+		})
+	} else {
+		err = r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+			films, pageInfo, err = r.findFilms(ctx, c, search, pagination)
+			return err
+		})
+	}
+
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("repository.FindFilms(): %w", err)
+	}
+
+	return films, pageInfo, nil
+}
+
+func (r *film) findFilms(ctx context.Context, q querier, search domain.FilmSearch, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	var (
+		args         []any
+		similarities []string
+	)
+
+	conditions := []string{"films.deleted_at IS NULL"}
+	ks := keyset{id: "films.id"}
+
+	from := "films"
+
+	if search.Query != "" {
+		args = append(args, search.Query)
+		from += fmt.Sprintf(" CROSS JOIN (SELECT websearch_to_tsquery('russian', $%d) || websearch_to_tsquery('english', $%d) AS query) AS fts", len(args), len(args))
+		conditions = append(conditions, "films.search_vector @@ fts.query")
+		ks.keys = append(ks.keys, sortKey{expr: "ts_rank(films.search_vector, fts.query)", cast: "real", desc: true})
+	}
+
+	if search.Title != "" {
+		args = append(args, search.Title)
+		if search.Fuzzy {
+			conditions = append(conditions, fmt.Sprintf("$%d <%% films.title", len(args)))
+			similarities = append(similarities, fmt.Sprintf("word_similarity($%d, films.title)", len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("films.title ILIKE '%%' || $%d || '%%'", len(args)))
+		}
+	}
+
+	if search.ActorName != "" {
+		args = append(args, search.ActorName)
+		if search.Fuzzy {
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = films.id AND actors.deleted_at IS NULL AND $%d <%% actors.name)", len(args)))
+			similarities = append(similarities, fmt.Sprintf("(SELECT MAX(word_similarity($%d, actors.name)) FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = films.id AND actors.deleted_at IS NULL)", len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = films.id AND actors.deleted_at IS NULL AND actors.name ILIKE '%%' || $%d || '%%')", len(args)))
+		}
+	}
+
+	if search.DirectorName != "" {
+		args = append(args, search.DirectorName)
+		if search.Fuzzy {
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_crew JOIN actors AS directors ON directors.id = film_crew.person_id WHERE film_crew.film_id = films.id AND film_crew.job = 'director' AND directors.deleted_at IS NULL AND $%d <%% directors.name)", len(args)))
+			similarities = append(similarities, fmt.Sprintf("(SELECT MAX(word_similarity($%d, directors.name)) FROM film_crew JOIN actors AS directors ON directors.id = film_crew.person_id WHERE film_crew.film_id = films.id AND film_crew.job = 'director' AND directors.deleted_at IS NULL)", len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM film_crew JOIN actors AS directors ON directors.id = film_crew.person_id WHERE film_crew.film_id = films.id AND film_crew.job = 'director' AND directors.deleted_at IS NULL AND directors.name ILIKE '%%' || $%d || '%%')", len(args)))
+		}
+	}
+
+	if search.Genre != "" {
+		args = append(args, search.Genre)
+		conditions = append(conditions, genreCondition(len(args)))
+	}
+
+	similarity := "NULL::real"
+	if len(similarities) != 0 {
+		similarity = "GREATEST(" + strings.Join(similarities, ", ") + ")"
+		ks.keys = append(ks.keys, sortKey{expr: similarity, cast: "real", desc: true})
+	}
+
+	if len(ks.keys) == 0 {
+		ks.keys = append(ks.keys, sortKey{expr: "films.rating", cast: "real", desc: true})
+	}
+
+	var (
+		pageInfo domain.PageInfo
+		err      error
+	)
+
+	pageInfo.Total, err = countRows(ctx, q, from, conditions, args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	limitClause, err := ks.paginate(pagination, &conditions, &args)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	sqlStr := fmt.Sprintf("SELECT %s, %s AS similarity, %s FROM %s WHERE %s ORDER BY %s%s", filmColumns, similarity, ks.sortValues(), from, strings.Join(conditions, " AND "), ks.orderBy(), limitClause)
+
+	rows, err := q.Query(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	films, sortValues, err := scanFilms(rows, true)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if len(films) > pagination.Limit {
+		films = films[:pagination.Limit]
+		pageInfo.NextCursor = ks.cursor(sortValues[pagination.Limit-1], films[pagination.Limit-1].ID)
+	}
+
+	return films, pageInfo, r.fillRelations(ctx, q, films)
+}
+
+func genreCondition(argNum int) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM film_genre JOIN genres ON genres.id = film_genre.genre_id WHERE film_genre.film_id = films.id AND LOWER(genres.name) = LOWER($%d))", argNum)
+}
+
+func selectCast(ctx context.Context, tx pgx.Tx, filmID int) ([]domain.CastMember, error) {
+	rows, err := tx.Query(ctx, "SELECT film_actor.actor_id, COALESCE(film_actor.character_name, ''), film_actor.billing, COALESCE(film_actor.role, '') FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = $1 AND actors.deleted_at IS NULL ORDER BY film_actor.billing ASC, film_actor.actor_id ASC", filmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		cast       []domain.CastMember
+		castMember domain.CastMember
+	)
+
+	for rows.Next() {
+		err = rows.Scan(&castMember.ActorID, &castMember.Character, &castMember.Billing, &castMember.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		cast = append(cast, castMember)
+	}
+
+	return cast, rows.Err()
+}
+
+func insertCast(ctx context.Context, tx pgx.Tx, filmID int, cast []domain.CastMember) error {
+	if len(cast) == 0 {
+		return nil
+	}
+
+	actorIDs := make([]int, 0, len(cast))
+	for _, castMember := range cast {
+		actorIDs = append(actorIDs, castMember.ActorID)
+	}
+
+	// deleted actors are still in the table, so the foreign key does not reject them
+	var actorsFound int
+	err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM actors WHERE id = ANY($1) AND deleted_at IS NULL", actorIDs).Scan(&actorsFound)
+	if err != nil {
+		return err
+	}
+
+	if actorsFound != len(actorIDs) {
+		return appErrors.ErrActorDoesNotExist
+	}
+
+	for _, castMember := range cast {
+		_, err := tx.Exec(ctx, "INSERT INTO film_actor(actor_id, film_id, character_name, billing, role) VALUES($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''))", castMember.ActorID, filmID, castMember.Character, castMember.Billing, castMember.Role)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == "P0001" {
+					return appErrors.ErrActorNotBornBeforeFilmRelease
+				}
+
+				if pgErr.Code == pgerrcode.ForeignKeyViolation {
+					return appErrors.ErrActorDoesNotExist
+				}
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertCrew(ctx context.Context, tx pgx.Tx, filmID int, crew []domain.CrewCredit) error {
+	if len(crew) == 0 {
+		return nil
+	}
+
+	personIDs := make([]int, 0, len(crew))
+	for _, credit := range crew {
+		personIDs = append(personIDs, credit.PersonID)
+	}
+
+	var personsFound, personsMentioned int
+	err := tx.QueryRow(ctx, "SELECT (SELECT COUNT(*) FROM actors WHERE id = ANY($1) AND deleted_at IS NULL), (SELECT COUNT(DISTINCT person_id) FROM unnest($1::int[]) AS person_id)", personIDs).Scan(&personsFound, &personsMentioned)
+	if err != nil {
+		return err
+	}
+
+	if personsFound != personsMentioned {
+		return appErrors.ErrPersonDoesNotExist
+	}
+
+	for _, credit := range crew {
+		_, err = tx.Exec(ctx, "INSERT INTO film_crew(film_id, person_id, job) VALUES($1, $2, $3)", filmID, credit.PersonID, credit.Job)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertFilmGenres(ctx context.Context, tx pgx.Tx, filmID int, genres []int) error {
+	for _, genreID := range genres {
+		_, err := tx.Exec(ctx, "INSERT INTO film_genre(film_id, genre_id) VALUES($1, $2) ON CONFLICT DO NOTHING", filmID, genreID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return appErrors.ErrGenreDoesNotExist
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *film) AddCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, filmID)
+		if err != nil {
+			return err
+		}
+
+		// the crew is a part of the film, so a client holding the previous ETag can not overwrite it
+		tag, err := tx.Exec(ctx, "UPDATE films SET version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)", filmID, version)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return versionMismatchOrNotFound(ctx, tx, "films", filmID)
+		}
+
+		var personExists bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM actors WHERE id = $1 AND deleted_at IS NULL)", personID).Scan(&personExists)
+		if err != nil {
+			return err
+		}
+
+		if !personExists {
+			return appErrors.ErrPersonDoesNotExist
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO film_crew(film_id, person_id, job) VALUES($1, $2, $3)", filmID, personID, job)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == pgerrcode.ForeignKeyViolation {
+					return appErrors.ErrPersonDoesNotExist
+				}
+
+				if pgErr.Code == pgerrcode.UniqueViolation {
+					return appErrors.ErrCrewCreditAlreadyExists
+				}
+			}
+
+			return err
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, filmID, domain.AuditActionUpdate, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.AddCrewCredit(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *film) DeleteCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, domain.AuditEntityFilm, filmID)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE films SET version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)", filmID, version)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return versionMismatchOrNotFound(ctx, tx, "films", filmID)
+		}
+
+		tag, err = tx.Exec(ctx, "DELETE FROM film_crew WHERE film_id = $1 AND person_id = $2 AND job = $3", filmID, personID, job)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
+
+		return writeAudit(ctx, tx, domain.AuditEntityFilm, filmID, domain.AuditActionUpdate, before)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.DeleteCrewCredit(): %w", err)
+	}
+
+	return nil
+}
+
+func scanFilms(rows pgx.Rows, withSimilarity bool) ([]domain.OutputFilm, [][]string, error) {
+	defer rows.Close()
+
+	var (
+		films          []domain.OutputFilm
+		sortValues     [][]string
+		curFilm        domain.OutputFilm
+		curReleaseDate time.Time
+		curSortValues  []string
+	)
+
+	for rows.Next() {
+		dest := []any{&curFilm.ID, &curFilm.Title, &curFilm.Description, &curReleaseDate, &curFilm.Rating, &curFilm.Version, &curFilm.LastModified, &curFilm.DeletedAt}
+		if withSimilarity {
+			curFilm.Similarity = nil
+			dest = append(dest, &curFilm.Similarity)
+		}
+
+		curSortValues = nil
+		err := rows.Scan(append(dest, &curSortValues)...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		curFilm.ReleaseDate = curReleaseDate.Format(time.DateOnly)
+		films = append(films, curFilm)
+		sortValues = append(sortValues, curSortValues)
+	}
+
+	return films, sortValues, rows.Err()
+}
+
+func (r *film) fillRelations(ctx context.Context, c querier, films []domain.OutputFilm) error {
+	err := r.fillActors(ctx, c, films)
+	if err != nil {
+		return err
+	}
+
+	err = r.fillCrew(ctx, c, films)
+	if err != nil {
+		return err
+	}
+
+	return r.fillGenres(ctx, c, films)
+}
+
+func (r *film) fillActors(ctx context.Context, c querier, films []domain.OutputFilm) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmIDs := make([]int, 0, len(films))
+	filmIdx := make(map[int]int, len(films))
+	for i := range films {
+		films[i].Actors = make([]domain.FilmOutputActor, 0)
+		filmIDs = append(filmIDs, films[i].ID)
+		filmIdx[films[i].ID] = i
+	}
+
+	rows, err := c.Query(ctx, "SELECT film_actor.film_id, actors.id, actors.name, actors.gender, actors.birthday, COALESCE(film_actor.character_name, ''), film_actor.billing, COALESCE(film_actor.role, '') FROM film_actor JOIN actors ON actors.id = film_actor.actor_id WHERE film_actor.film_id = ANY($1) AND actors.deleted_at IS NULL ORDER BY film_actor.film_id ASC, film_actor.billing ASC, actors.id ASC", filmIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		curFilmID   int
+		curActor    domain.FilmOutputActor
+		curGender   bool
+		curBirthday time.Time
+	)
+
+	for rows.Next() {
+		err = rows.Scan(&curFilmID, &curActor.ID, &curActor.Name, &curGender, &curBirthday, &curActor.Character, &curActor.Billing, &curActor.Role)
+		if err != nil {
+			return err
+		}
+
+		if curGender {
+			curActor.Gender = "female"
+		} else {
+			curActor.Gender = "male"
+		}
+
+		curActor.Birthday = curBirthday.Format(time.DateOnly)
+
+		i := filmIdx[curFilmID]
+		films[i].Actors = append(films[i].Actors, curActor)
+	}
+
+	return rows.Err()
+}
+
+func (r *film) fillCrew(ctx context.Context, c querier, films []domain.OutputFilm) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmIDs := make([]int, 0, len(films))
+	filmIdx := make(map[int]int, len(films))
+	for i := range films {
+		films[i].Crew = make([]domain.FilmOutputCrew, 0)
+		filmIDs = append(filmIDs, films[i].ID)
+		filmIdx[films[i].ID] = i
+	}
+
+	rows, err := c.Query(ctx, "SELECT film_crew.film_id, actors.id, actors.name, film_crew.job FROM film_crew JOIN actors ON actors.id = film_crew.person_id WHERE film_crew.film_id = ANY($1) AND actors.deleted_at IS NULL ORDER BY film_crew.film_id ASC, film_crew.job ASC, actors.id ASC", filmIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		curFilmID int
+		curCrew   domain.FilmOutputCrew
+	)
+
+	for rows.Next() {
+		err = rows.Scan(&curFilmID, &curCrew.ID, &curCrew.Name, &curCrew.Job)
+		if err != nil {
+			return err
+		}
+
+		i := filmIdx[curFilmID]
+		films[i].Crew = append(films[i].Crew, curCrew)
+	}
+
+	return rows.Err()
+}
+
+func (r *film) fillGenres(ctx context.Context, c querier, films []domain.OutputFilm) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmIDs := make([]int, 0, len(films))
+	filmIdx := make(map[int]int, len(films))
+	for i := range films {
+		films[i].Genres = make([]domain.OutputGenre, 0)
+		filmIDs = append(filmIDs, films[i].ID)
+		filmIdx[films[i].ID] = i
+	}
+
+	rows, err := c.Query(ctx, "SELECT film_genre.film_id, genres.id, genres.name FROM film_genre JOIN genres ON genres.id = film_genre.genre_id WHERE film_genre.film_id = ANY($1) ORDER BY film_genre.film_id ASC, genres.name ASC", filmIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		curFilmID int
+		curGenre  domain.OutputGenre
+	)
+
+	for rows.Next() {
+		err = rows.Scan(&curFilmID, &curGenre.ID, &curGenre.Name)
+		if err != nil {
+			return err
+		}
+
+		i := filmIdx[curFilmID]
+		films[i].Genres = append(films[i].Genres, curGenre)
 	}
 
-	return films, nil
+	return rows.Err()
 }
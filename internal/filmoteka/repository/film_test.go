@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+func TestReadFilmsSkipsActorsInTrash(t *testing.T) {
+	pg, _ := testPostgres(t)
+	ar := NewActor(pg)
+	fr := NewFilm(pg)
+
+	ctx := context.Background()
+	name := fmt.Sprintf("trashed actor %d", time.Now().UnixNano())
+
+	actorID, err := ar.CreateActor(ctx, name, false, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	filmID, err := fr.CreateFilm(ctx, name, "", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5, []domain.CastMember{{ActorID: actorID, Billing: 1, Role: domain.RoleLead}}, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pg.Exec(ctx, "DELETE FROM films WHERE id = $1", filmID)
+		_, _ = pg.Exec(ctx, "DELETE FROM actors WHERE id = $1", actorID)
+	})
+
+	films, _, err := fr.ReadFilms(ctx, "title", "asc", domain.FilmFilter{ActorID: actorID}, domain.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, films, 1)
+
+	before, err := fr.ReadFilm(ctx, filmID)
+	require.NoError(t, err)
+
+	err = ar.DeleteActor(ctx, actorID, 0)
+	require.NoError(t, err)
+
+	films, _, err = fr.ReadFilms(ctx, "title", "asc", domain.FilmFilter{ActorID: actorID}, domain.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	// the film lost an actor, so it is modified even though the actor is no longer taken into account
+	after, err := fr.ReadFilm(ctx, filmID)
+	require.NoError(t, err)
+	require.True(t, after.LastModified.After(before.LastModified))
+}
+
+func TestPatchFilmRemovesDescription(t *testing.T) {
+	pg, _ := testPostgres(t)
+	fr := NewFilm(pg)
+
+	ctx := context.Background()
+	title := fmt.Sprintf("described film %d", time.Now().UnixNano())
+
+	filmID, err := fr.CreateFilm(ctx, title, "some kind of film", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5, nil, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pg.Exec(ctx, "DELETE FROM films WHERE id = $1", filmID)
+	})
+
+	// "description": null in a merge patch comes as an empty description
+	description := ""
+	err = fr.PatchFilm(ctx, filmID, 0, domain.FilmPatch{Description: &description})
+	require.NoError(t, err)
+
+	var isNull bool
+	err = pg.QueryRow(ctx, "SELECT description IS NULL FROM films WHERE id = $1", filmID).Scan(&isNull)
+	require.NoError(t, err)
+	require.True(t, isNull)
+
+	film, err := fr.ReadFilm(ctx, filmID)
+	require.NoError(t, err)
+	require.Empty(t, film.Description)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+var (
+	_ domain.GenreRepository = (*genre)(nil)
+)
+
+type genre struct {
+	db *postgres
+}
+
+func NewGenre(pg *postgres) *genre {
+	return &genre{db: pg}
+}
+
+func (r *genre) CreateGenre(ctx context.Context, name string) (int, error) {
+	var id int
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, "INSERT INTO genres(name) VALUES($1) RETURNING id", name).Scan(&id)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return appErrors.ErrGenreAlreadyExists
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.CreateGenre(): %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *genre) UpdateGenre(ctx context.Context, id int, name string) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE genres SET name = $1 WHERE id = $2", name, id)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return appErrors.ErrGenreAlreadyExists
+			}
+
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
+
+		return touchFilmsOfGenre(ctx, tx, id)
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.UpdateGenre(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *genre) DeleteGenre(ctx context.Context, id int) error {
+	err := r.db.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := touchFilmsOfGenre(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "DELETE FROM genres WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNotFoundInDB
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.DeleteGenre(): %w", err)
+	}
+
+	return nil
+}
+
+func (r *genre) ReadGenres(ctx context.Context) ([]domain.OutputGenre, error) {
+	genres := make([]domain.OutputGenre, 0)
+	err := r.db.WithConnection(ctx, func(ctx context.Context, c *pgxpool.Conn) error {
+		rows, err := c.Query(ctx, "SELECT id, name FROM genres ORDER BY name ASC")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var curGenre domain.OutputGenre
+		for rows.Next() {
+			err = rows.Scan(&curGenre.ID, &curGenre.Name)
+			if err != nil {
+				return err
+			}
+
+			genres = append(genres, curGenre)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.ReadGenres(): %w", err)
+	}
+
+	return genres, nil
+}
+
+func touchFilmsOfGenre(ctx context.Context, tx pgx.Tx, genreID int) error {
+	_, err := tx.Exec(ctx, "UPDATE films SET updated_at = now() WHERE id IN (SELECT film_id FROM film_genre WHERE genre_id = $1)", genreID)
+	return err
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+// benchDSNEnv holds the DSN of a disposable database used by the listing benchmarks and the tests that need a database,
+// they are skipped if it is not set.
+const benchDSNEnv = "FILMOTEKA_BENCH_DSN"
+
+const (
+	benchFilms         = 100
+	benchActorsPerFilm = 10
+)
+
+// a page of films is counted and fetched along with its actors, crew and genres, a page of actors - along with their films.
+const (
+	filmPageQueries  = 5
+	actorPageQueries = 3
+)
+
+type queryCounter struct {
+	count atomic.Int64
+}
+
+func (qc *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	qc.count.Add(1)
+	return ctx
+}
+
+func (qc *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func testPostgres(tb testing.TB) (*postgres, *queryCounter) {
+	tb.Helper()
+
+	dsn := os.Getenv(benchDSNEnv)
+	if dsn == "" {
+		tb.Skip(benchDSNEnv + " is not set")
+	}
+
+	m, err := migrate.New("file://../../../migrations", dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	err = ApplyMigrations(m)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	qc := &queryCounter{}
+	config.ConnConfig.Tracer = qc
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(pool.Close)
+
+	return NewPostgres(pool), qc
+}
+
+func seedCatalogue(b *testing.B, pg *postgres) {
+	b.Helper()
+
+	ctx := context.Background()
+	ar := NewActor(pg)
+	fr := NewFilm(pg)
+
+	var filmIDs, actorIDs []int
+	for i := 0; i < benchFilms; i++ {
+		cast := make([]domain.CastMember, 0, benchActorsPerFilm)
+		for j := 0; j < benchActorsPerFilm; j++ {
+			id, err := ar.CreateActor(ctx, fmt.Sprintf("bench actor %d-%d", i, j), j%2 == 0, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			cast = append(cast, domain.CastMember{ActorID: id, Billing: j + 1, Role: domain.RoleSupporting})
+		}
+
+		id, err := fr.CreateFilm(ctx, fmt.Sprintf("bench film %d", i), "bench", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5, cast, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		filmIDs = append(filmIDs, id)
+		for _, castMember := range cast {
+			actorIDs = append(actorIDs, castMember.ActorID)
+		}
+	}
+
+	b.Cleanup(func() {
+		_, _ = pg.Exec(ctx, "DELETE FROM films WHERE id = ANY($1)", filmIDs)
+		_, _ = pg.Exec(ctx, "DELETE FROM actors WHERE id = ANY($1)", actorIDs)
+	})
+}
+
+func reportQueries(b *testing.B, qc *queryCounter, maxQueries int) {
+	b.Helper()
+
+	perOp := float64(qc.count.Load()) / float64(b.N)
+	b.ReportMetric(perOp, "queries/op")
+
+	if perOp > float64(maxQueries) {
+		b.Fatalf("expected at most %d queries per page, got %.1f", maxQueries, perOp)
+	}
+}
+
+func BenchmarkReadFilms(b *testing.B) {
+	pg, qc := testPostgres(b)
+	seedCatalogue(b, pg)
+	r := NewFilm(pg)
+
+	qc.count.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := r.ReadFilms(context.Background(), "rating", "desc", domain.FilmFilter{}, domain.Pagination{Page: 1, Limit: benchFilms})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	reportQueries(b, qc, filmPageQueries)
+}
+
+func BenchmarkFindFilms(b *testing.B) {
+	pg, qc := testPostgres(b)
+	seedCatalogue(b, pg)
+	r := NewFilm(pg)
+
+	qc.count.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := r.FindFilms(context.Background(), domain.FilmSearch{Title: "bench", ActorName: "bench"}, domain.Pagination{Page: 1, Limit: benchFilms})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	reportQueries(b, qc, filmPageQueries)
+}
+
+func BenchmarkReadActors(b *testing.B) {
+	pg, qc := testPostgres(b)
+	seedCatalogue(b, pg)
+	r := NewActor(pg)
+
+	qc.count.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := r.ReadActors(context.Background(), "film_count", "desc", domain.Pagination{Page: 1, Limit: benchFilms})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	reportQueries(b, qc, actorPageQueries)
+}
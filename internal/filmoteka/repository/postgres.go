@@ -4,14 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type postgres struct {
 	*pgxpool.Pool
 }
@@ -93,3 +101,22 @@ func (p *postgres) WithConnection(ctx context.Context, connFunc func(context.Con
 
 	return nil
 }
+
+func versionMismatchOrNotFound(ctx context.Context, q querier, table string, id int) error {
+	var exists bool
+	err := q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return appErrors.ErrVersionMismatch
+	}
+
+	return appErrors.ErrNotFoundInDB
+}
+
+func setSimilarityThreshold(ctx context.Context, tx pgx.Tx, threshold float64) error {
+	_, err := tx.Exec(ctx, "SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)", strconv.FormatFloat(threshold, 'f', -1, 64))
+	return err
+}
@@ -29,8 +29,8 @@ func (s *actor) CreateActor(ctx context.Context, name string, gender bool, birth
 	return id, nil
 }
 
-func (s *actor) UpdateActor(ctx context.Context, id int, name string, gender *bool, birthday time.Time) error {
-	err := s.repo.UpdateActor(ctx, id, name, gender, birthday)
+func (s *actor) UpdateActor(ctx context.Context, id int, version int, name string, gender bool, birthday time.Time) error {
+	err := s.repo.UpdateActor(ctx, id, version, name, gender, birthday)
 	if err != nil {
 		return fmt.Errorf("service.UpdateActor(): %w", err)
 	}
@@ -38,8 +38,26 @@ func (s *actor) UpdateActor(ctx context.Context, id int, name string, gender *bo
 	return nil
 }
 
-func (s *actor) DeleteActor(ctx context.Context, id int) error {
-	err := s.repo.DeleteActor(ctx, id)
+func (s *actor) PatchActor(ctx context.Context, id int, version int, patch domain.ActorPatch) error {
+	err := s.repo.PatchActor(ctx, id, version, patch)
+	if err != nil {
+		return fmt.Errorf("service.PatchActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *actor) RevertActor(ctx context.Context, id int, version int, revision int) error {
+	err := s.repo.RevertActor(ctx, id, version, revision)
+	if err != nil {
+		return fmt.Errorf("service.RevertActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *actor) DeleteActor(ctx context.Context, id int, version int) error {
+	err := s.repo.DeleteActor(ctx, id, version)
 	if err != nil {
 		return fmt.Errorf("service.DeleteActor(): %w", err)
 	}
@@ -47,11 +65,65 @@ func (s *actor) DeleteActor(ctx context.Context, id int) error {
 	return nil
 }
 
-func (s *actor) ReadActors(ctx context.Context, page int, limit int) ([]domain.OutputActor, error) {
-	actors, err := s.repo.ReadActors(ctx, page, limit)
+func (s *actor) RestoreActor(ctx context.Context, id int) error {
+	err := s.repo.RestoreActor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.RestoreActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *actor) PurgeActor(ctx context.Context, id int) error {
+	err := s.repo.PurgeActor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.PurgeActor(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *actor) ReadDeletedActors(ctx context.Context, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors, pageInfo, err := s.repo.ReadDeletedActors(ctx, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadDeletedActors(): %w", err)
+	}
+
+	return actors, pageInfo, nil
+}
+
+func (s *actor) ReadActorHistory(ctx context.Context, id int, pagination domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	records, pageInfo, err := s.repo.ReadActorHistory(ctx, id, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadActorHistory(): %w", err)
+	}
+
+	return records, pageInfo, nil
+}
+
+func (s *actor) ReadActor(ctx context.Context, id int) (domain.OutputActor, error) {
+	actor, err := s.repo.ReadActor(ctx, id)
+	if err != nil {
+		return domain.OutputActor{}, fmt.Errorf("service.ReadActor(): %w", err)
+	}
+
+	return actor, nil
+}
+
+func (s *actor) ReadActors(ctx context.Context, field string, order string, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors, pageInfo, err := s.repo.ReadActors(ctx, field, order, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadActors(): %w", err)
+	}
+
+	return actors, pageInfo, nil
+}
+
+func (s *actor) FindActors(ctx context.Context, search domain.ActorSearch, pagination domain.Pagination) ([]domain.OutputActor, domain.PageInfo, error) {
+	actors, pageInfo, err := s.repo.FindActors(ctx, search, pagination)
 	if err != nil {
-		return nil, fmt.Errorf("service.ReadActors(): %w", err)
+		return nil, domain.PageInfo{}, fmt.Errorf("service.FindActors(): %w", err)
 	}
 
-	return actors, nil
+	return actors, pageInfo, nil
 }
@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -14,15 +20,27 @@ var (
 	_ domain.AuthorizationService = (*autorization)(nil)
 )
 
+const apiKeyPrefix = "fmk_"
+
+// dummyHash is compared with the passwords of unknown logins, so that checking them takes as long as for existing users.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("filmoteka"), bcrypt.DefaultCost)
+
 type autorization struct {
-	repo domain.AuthorizationRepository
+	repo     domain.AuthorizationRepository
+	policy   domain.CredentialsPolicy
+	throttle domain.LoginThrottlePolicy
 }
 
-func NewAuthorization(repo domain.AuthorizationRepository) *autorization {
-	return &autorization{repo: repo}
+func NewAuthorization(repo domain.AuthorizationRepository, policy domain.CredentialsPolicy, throttle domain.LoginThrottlePolicy) *autorization {
+	return &autorization{repo: repo, policy: policy, throttle: throttle}
 }
 
 func (s *autorization) Register(ctx context.Context, login string, password string) error {
+	err := policyError(append(checkLogin(s.policy, login), checkPassword(s.policy, login, password)...))
+	if err != nil {
+		return fmt.Errorf("service.Register(): %w", err)
+	}
+
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("service.Register(): %w", err)
@@ -36,25 +54,324 @@ func (s *autorization) Register(ctx context.Context, login string, password stri
 	return nil
 }
 
-func (s *autorization) CheckAuth(ctx context.Context, login string, password string) error {
-	hash, err := s.repo.GetPasswordHash(ctx, login)
+// An unknown login and a wrong password result in the same appErrors.ErrInvalidCredentials, so the logins of users are not revealed.
+func (s *autorization) CheckAuth(ctx context.Context, login string, password string, ip string) error {
+	// the attempt is counted as failed before the password is checked, so parallel attempts can not all get past the limits
+	wait, err := s.repo.ReserveLoginAttempt(ctx, login, ip, s.throttle, time.Now())
 	if err != nil {
 		return fmt.Errorf("service.CheckAuth(): %w", err)
 	}
 
+	if wait > 0 {
+		return fmt.Errorf("service.CheckAuth(): %w", &appErrors.RetryAfterError{Err: appErrors.ErrTooManyLoginAttempts, RetryAfter: wait})
+	}
+
+	hash, err := s.repo.GetPasswordHash(ctx, login)
+	if err != nil && !errors.Is(err, appErrors.ErrUserNotFound) {
+		return fmt.Errorf("service.CheckAuth(): %w", err)
+	}
+
+	isUnknown := err != nil
+	if isUnknown {
+		hash = string(dummyHash)
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil || isUnknown {
+		return fmt.Errorf("service.CheckAuth(): %w", appErrors.ErrInvalidCredentials)
+	}
+
+	err = s.repo.ReleaseLoginAttempt(ctx, login, ip)
 	if err != nil {
-		return fmt.Errorf("service.CheckAuth(): %w", appErrors.ErrWrongPassword)
+		return fmt.Errorf("service.CheckAuth(): %w", err)
+	}
+
+	account, err := s.repo.GetAccount(ctx, login)
+	if err != nil {
+		return fmt.Errorf("service.CheckAuth(): %w", err)
+	}
+
+	if account.IsDisabled {
+		return fmt.Errorf("service.CheckAuth(): %w", appErrors.ErrUserIsDisabled)
 	}
 
 	return nil
 }
 
-func (s *autorization) IsAdmin(ctx context.Context, login string) (bool, error) {
-	isAdmin, err := s.repo.IsAdmin(ctx, login)
+func (s *autorization) GetRole(ctx context.Context, login string) (domain.Role, error) {
+	role, err := s.repo.GetRole(ctx, login)
+	if err != nil {
+		return domain.Role{}, fmt.Errorf("service.GetRole(): %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *autorization) ReadRoles(ctx context.Context) ([]domain.Role, error) {
+	roles, err := s.repo.ReadRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReadRoles(): %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s *autorization) CreateRefreshToken(ctx context.Context, login string, expiresAt time.Time) (string, error) {
+	refreshToken, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("service.CreateRefreshToken(): %w", err)
+	}
+
+	err = s.repo.CreateRefreshToken(ctx, login, hashToken(refreshToken), expiresAt)
+	if err != nil {
+		return "", fmt.Errorf("service.CreateRefreshToken(): %w", err)
+	}
+
+	return refreshToken, nil
+}
+
+func (s *autorization) RotateRefreshToken(ctx context.Context, refreshToken string, expiresAt time.Time) (string, string, error) {
+	newRefreshToken, err := generateToken()
+	if err != nil {
+		return "", "", fmt.Errorf("service.RotateRefreshToken(): %w", err)
+	}
+
+	login, err := s.repo.RotateRefreshToken(ctx, hashToken(refreshToken), hashToken(newRefreshToken), expiresAt)
 	if err != nil {
-		return false, fmt.Errorf("service.IsAdmin(): %w", err)
+		return "", "", fmt.Errorf("service.RotateRefreshToken(): %w", err)
 	}
 
-	return isAdmin, nil
+	return login, newRefreshToken, nil
+}
+
+func (s *autorization) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	err := s.repo.RevokeRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("service.RevokeRefreshToken(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	err := s.repo.RevokeToken(ctx, tokenID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("service.RevokeToken(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	isRevoked, err := s.repo.IsTokenRevoked(ctx, tokenID)
+	if err != nil {
+		return false, fmt.Errorf("service.IsTokenRevoked(): %w", err)
+	}
+
+	return isRevoked, nil
+}
+
+func (s *autorization) CheckSession(ctx context.Context, login string, issuedAt time.Time) error {
+	account, err := s.repo.GetAccount(ctx, login)
+	if err != nil {
+		return fmt.Errorf("service.CheckSession(): %w", err)
+	}
+
+	if account.IsDisabled {
+		return fmt.Errorf("service.CheckSession(): %w", appErrors.ErrUserIsDisabled)
+	}
+
+	// token issue times are stored with second precision
+	if account.SessionsRevokedAt != nil && issuedAt.Before(account.SessionsRevokedAt.Truncate(time.Second)) {
+		return fmt.Errorf("service.CheckSession(): %w", appErrors.ErrTokenIsInvalid)
+	}
+
+	return nil
+}
+
+func (s *autorization) ChangePassword(ctx context.Context, login string, password string, newPassword string, ip string) error {
+	err := policyError(checkPassword(s.policy, login, newPassword))
+	if err != nil {
+		return fmt.Errorf("service.ChangePassword(): %w", err)
+	}
+
+	err = s.CheckAuth(ctx, login, password, ip)
+	if err != nil {
+		return fmt.Errorf("service.ChangePassword(): %w", err)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("service.ChangePassword(): %w", err)
+	}
+
+	err = s.repo.SetPassword(ctx, login, string(passwordHash), time.Now())
+	if err != nil {
+		return fmt.Errorf("service.ChangePassword(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) CreatePasswordResetToken(ctx context.Context, login string, expiresAt time.Time) (string, error) {
+	resetToken, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("service.CreatePasswordResetToken(): %w", err)
+	}
+
+	err = s.repo.CreatePasswordResetToken(ctx, login, hashToken(resetToken), expiresAt)
+	if err != nil {
+		return "", fmt.Errorf("service.CreatePasswordResetToken(): %w", err)
+	}
+
+	return resetToken, nil
+}
+
+func (s *autorization) ResetPassword(ctx context.Context, resetToken string, newPassword string) error {
+	err := policyError(checkPassword(s.policy, "", newPassword))
+	if err != nil {
+		return fmt.Errorf("service.ResetPassword(): %w", err)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("service.ResetPassword(): %w", err)
+	}
+
+	err = s.repo.ResetPassword(ctx, hashToken(resetToken), string(passwordHash), time.Now())
+	if err != nil {
+		return fmt.Errorf("service.ResetPassword(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) ReadUsers(ctx context.Context, pagination domain.Pagination) ([]domain.User, domain.PageInfo, error) {
+	users, pageInfo, err := s.repo.ReadUsers(ctx, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadUsers(): %w", err)
+	}
+
+	return users, pageInfo, nil
+}
+
+func (s *autorization) SetRole(ctx context.Context, login string, role string) error {
+	err := s.repo.SetRole(ctx, login, role)
+	if err != nil {
+		return fmt.Errorf("service.SetRole(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) SetDisabled(ctx context.Context, login string, isDisabled bool) error {
+	err := s.repo.SetDisabled(ctx, login, isDisabled)
+	if err != nil {
+		return fmt.Errorf("service.SetDisabled(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) DeleteUser(ctx context.Context, login string) error {
+	err := s.repo.DeleteUser(ctx, login)
+	if err != nil {
+		return fmt.Errorf("service.DeleteUser(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) ReadLockouts(ctx context.Context) ([]domain.Lockout, error) {
+	now := time.Now()
+	failures, err := s.repo.ReadLockouts(ctx, now.Add(-s.throttle.LockoutDuration), s.throttle.MaxFailures, s.throttle.MaxFailuresPerIP)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReadLockouts(): %w", err)
+	}
+
+	lockouts := make([]domain.Lockout, 0, len(failures))
+	for _, f := range failures {
+		lockouts = append(lockouts, domain.Lockout{
+			Kind:        f.Kind,
+			Subject:     f.Subject,
+			Failures:    f.Failures,
+			LockedUntil: f.LastFailureAt.Add(s.throttle.LockoutDuration),
+		})
+	}
+
+	return lockouts, nil
+}
+
+// Failed attempts from IP addresses are kept.
+func (s *autorization) Unlock(ctx context.Context, login string) error {
+	err := s.repo.ResetLoginFailures(ctx, login)
+	if err != nil {
+		return fmt.Errorf("service.Unlock(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) CreateAPIKey(ctx context.Context, name string, scope string) (domain.CreatedAPIKey, error) {
+	if _, ok := domain.APIKeyScopes[scope]; !ok {
+		return domain.CreatedAPIKey{}, fmt.Errorf("service.CreateAPIKey(): %w", appErrors.ErrUnknownAPIKeyScope)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return domain.CreatedAPIKey{}, fmt.Errorf("service.CreateAPIKey(): %w", err)
+	}
+
+	// the prefix makes the keys easy to find in configs and logs where they should not be
+	key := apiKeyPrefix + token
+
+	apiKey, err := s.repo.CreateAPIKey(ctx, name, scope, hashToken(key))
+	if err != nil {
+		return domain.CreatedAPIKey{}, fmt.Errorf("service.CreateAPIKey(): %w", err)
+	}
+
+	return domain.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *autorization) ReadAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	apiKeys, err := s.repo.ReadAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReadAPIKeys(): %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (s *autorization) RevokeAPIKey(ctx context.Context, id int) error {
+	err := s.repo.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.RevokeAPIKey(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *autorization) CheckAPIKey(ctx context.Context, key string) (domain.APIKey, error) {
+	apiKey, err := s.repo.CheckAPIKey(ctx, hashToken(key))
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("service.CheckAPIKey(): %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func generateToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// SHA-256 is enough for the tokens and API keys, since they are random and long.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
 }
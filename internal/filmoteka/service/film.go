@@ -20,8 +20,8 @@ func NewFilm(repo domain.FilmRepository) *film {
 	return &film{repo: repo}
 }
 
-func (s *film) CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, actors []int) (int, error) {
-	id, err := s.repo.CreateFilm(ctx, title, description, releaseDate, rating, actors)
+func (s *film) CreateFilm(ctx context.Context, title string, description string, releaseDate time.Time, rating float32, cast []domain.CastMember, genres []int) (int, error) {
+	id, err := s.repo.CreateFilm(ctx, title, description, releaseDate, rating, cast, genres)
 	if err != nil {
 		return 0, fmt.Errorf("service.CreateFilm(): %w", err)
 	}
@@ -29,8 +29,8 @@ func (s *film) CreateFilm(ctx context.Context, title string, description string,
 	return id, nil
 }
 
-func (s *film) UpdateFilm(ctx context.Context, id int, title string, description string, releaseDate time.Time, rating *float32, actors []int) error {
-	err := s.repo.UpdateFilm(ctx, id, title, description, releaseDate, rating, actors)
+func (s *film) UpdateFilm(ctx context.Context, id int, version int, title string, description string, releaseDate time.Time, rating float32, cast []domain.CastMember, genres []int) error {
+	err := s.repo.UpdateFilm(ctx, id, version, title, description, releaseDate, rating, cast, genres)
 	if err != nil {
 		return fmt.Errorf("service.UpdateFilm(): %w", err)
 	}
@@ -38,8 +38,26 @@ func (s *film) UpdateFilm(ctx context.Context, id int, title string, description
 	return nil
 }
 
-func (s *film) DeleteFilm(ctx context.Context, id int) error {
-	err := s.repo.DeleteFilm(ctx, id)
+func (s *film) PatchFilm(ctx context.Context, id int, version int, patch domain.FilmPatch) error {
+	err := s.repo.PatchFilm(ctx, id, version, patch)
+	if err != nil {
+		return fmt.Errorf("service.PatchFilm(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *film) RevertFilm(ctx context.Context, id int, version int, revision int) error {
+	err := s.repo.RevertFilm(ctx, id, version, revision)
+	if err != nil {
+		return fmt.Errorf("service.RevertFilm(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *film) DeleteFilm(ctx context.Context, id int, version int) error {
+	err := s.repo.DeleteFilm(ctx, id, version)
 	if err != nil {
 		return fmt.Errorf("service.DeleteFilm(): %w", err)
 	}
@@ -47,20 +65,83 @@ func (s *film) DeleteFilm(ctx context.Context, id int) error {
 	return nil
 }
 
-func (s *film) ReadFilms(ctx context.Context, field string, order string, page int, limit int) ([]domain.OutputFilm, error) {
-	films, err := s.repo.ReadFilms(ctx, field, order, page, limit)
+func (s *film) RestoreFilm(ctx context.Context, id int) error {
+	err := s.repo.RestoreFilm(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.RestoreFilm(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *film) PurgeFilm(ctx context.Context, id int) error {
+	err := s.repo.PurgeFilm(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("service.ReadFilms(): %w", err)
+		return fmt.Errorf("service.PurgeFilm(): %w", err)
 	}
 
-	return films, nil
+	return nil
 }
 
-func (s *film) FindFilms(ctx context.Context, filmTitleFragment string, actorNameFragment string, page int, limit int) ([]domain.OutputFilm, error) {
-	films, err := s.repo.FindFilms(ctx, filmTitleFragment, actorNameFragment, page, limit)
+func (s *film) ReadDeletedFilms(ctx context.Context, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	films, pageInfo, err := s.repo.ReadDeletedFilms(ctx, pagination)
 	if err != nil {
-		return nil, fmt.Errorf("service.FindFilms(): %w", err)
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadDeletedFilms(): %w", err)
 	}
 
-	return films, nil
+	return films, pageInfo, nil
+}
+
+func (s *film) ReadFilmHistory(ctx context.Context, id int, pagination domain.Pagination) ([]domain.AuditRecord, domain.PageInfo, error) {
+	records, pageInfo, err := s.repo.ReadFilmHistory(ctx, id, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadFilmHistory(): %w", err)
+	}
+
+	return records, pageInfo, nil
+}
+
+func (s *film) ReadFilm(ctx context.Context, id int) (domain.OutputFilm, error) {
+	film, err := s.repo.ReadFilm(ctx, id)
+	if err != nil {
+		return domain.OutputFilm{}, fmt.Errorf("service.ReadFilm(): %w", err)
+	}
+
+	return film, nil
+}
+
+func (s *film) ReadFilms(ctx context.Context, field string, order string, filter domain.FilmFilter, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	films, pageInfo, err := s.repo.ReadFilms(ctx, field, order, filter, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.ReadFilms(): %w", err)
+	}
+
+	return films, pageInfo, nil
+}
+
+func (s *film) FindFilms(ctx context.Context, search domain.FilmSearch, pagination domain.Pagination) ([]domain.OutputFilm, domain.PageInfo, error) {
+	films, pageInfo, err := s.repo.FindFilms(ctx, search, pagination)
+	if err != nil {
+		return nil, domain.PageInfo{}, fmt.Errorf("service.FindFilms(): %w", err)
+	}
+
+	return films, pageInfo, nil
+}
+
+func (s *film) AddCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error {
+	err := s.repo.AddCrewCredit(ctx, filmID, version, personID, job)
+	if err != nil {
+		return fmt.Errorf("service.AddCrewCredit(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *film) DeleteCrewCredit(ctx context.Context, filmID int, version int, personID int, job string) error {
+	err := s.repo.DeleteCrewCredit(ctx, filmID, version, personID, job)
+	if err != nil {
+		return fmt.Errorf("service.DeleteCrewCredit(): %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+var (
+	_ domain.GenreService = (*genre)(nil)
+)
+
+type genre struct {
+	repo domain.GenreRepository
+}
+
+func NewGenre(repo domain.GenreRepository) *genre {
+	return &genre{repo: repo}
+}
+
+func (s *genre) CreateGenre(ctx context.Context, name string) (int, error) {
+	id, err := s.repo.CreateGenre(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("service.CreateGenre(): %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *genre) UpdateGenre(ctx context.Context, id int, name string) error {
+	err := s.repo.UpdateGenre(ctx, id, name)
+	if err != nil {
+		return fmt.Errorf("service.UpdateGenre(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *genre) DeleteGenre(ctx context.Context, id int) error {
+	err := s.repo.DeleteGenre(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.DeleteGenre(): %w", err)
+	}
+
+	return nil
+}
+
+func (s *genre) ReadGenres(ctx context.Context) ([]domain.OutputGenre, error) {
+	genres, err := s.repo.ReadGenres(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReadGenres(): %w", err)
+	}
+
+	return genres, nil
+}
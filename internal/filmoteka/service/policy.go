@@ -0,0 +1,106 @@
+package service
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
+	"github.com/PoorMercymain/filmoteka/internal/filmoteka/domain"
+)
+
+const bcryptMaxPasswordLength = 72
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, password := range strings.Fields(commonPasswordList) {
+		passwords[strings.ToLower(password)] = struct{}{}
+	}
+
+	return passwords
+}()
+
+func checkLogin(policy domain.CredentialsPolicy, login string) []error {
+	var violations []error
+
+	length := utf8.RuneCountInString(login)
+	if length < policy.LoginMinLength {
+		violations = append(violations, fmt.Errorf("%w (%d characters is the minimum)", appErrors.ErrLoginTooShort, policy.LoginMinLength))
+	}
+
+	if policy.LoginMaxLength > 0 && length > policy.LoginMaxLength {
+		violations = append(violations, fmt.Errorf("%w (%d characters is the limit)", appErrors.ErrLoginTooLong, policy.LoginMaxLength))
+	}
+
+	if policy.LoginCharacters != nil && !policy.LoginCharacters.MatchString(login) {
+		violations = append(violations, appErrors.ErrLoginHasForbiddenCharacter)
+	}
+
+	return violations
+}
+
+// The password is not compared to the login if it is empty.
+func checkPassword(policy domain.CredentialsPolicy, login string, password string) []error {
+	var violations []error
+
+	if utf8.RuneCountInString(password) < policy.PasswordMinLength {
+		violations = append(violations, fmt.Errorf("%w (%d characters is the minimum)", appErrors.ErrPasswordTooShort, policy.PasswordMinLength))
+	}
+
+	if len(password) > bcryptMaxPasswordLength {
+		violations = append(violations, appErrors.ErrPasswordTooLong)
+	}
+
+	var hasUppercase, hasLowercase, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUppercase = true
+		case unicode.IsLower(r):
+			hasLowercase = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSpecial = true
+		}
+	}
+
+	if policy.PasswordRequireUppercase && !hasUppercase {
+		violations = append(violations, appErrors.ErrPasswordHasNoUppercase)
+	}
+
+	if policy.PasswordRequireLowercase && !hasLowercase {
+		violations = append(violations, appErrors.ErrPasswordHasNoLowercase)
+	}
+
+	if policy.PasswordRequireDigit && !hasDigit {
+		violations = append(violations, appErrors.ErrPasswordHasNoDigit)
+	}
+
+	if policy.PasswordRequireSpecial && !hasSpecial {
+		violations = append(violations, appErrors.ErrPasswordHasNoSpecial)
+	}
+
+	if login != "" && strings.EqualFold(password, login) {
+		violations = append(violations, appErrors.ErrPasswordEqualsLogin)
+	}
+
+	if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+		violations = append(violations, appErrors.ErrPasswordIsCommon)
+	}
+
+	return violations
+}
+
+func policyError(violations []error) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &appErrors.PolicyError{Violations: violations}
+}
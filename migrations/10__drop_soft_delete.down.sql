@@ -0,0 +1,7 @@
+BEGIN;
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+DROP INDEX IF EXISTS films_deleted_at_idx;
+
+ALTER TABLE actors DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE films DROP COLUMN IF EXISTS deleted_at;
+COMMIT;
\ No newline at end of file
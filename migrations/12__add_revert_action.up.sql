@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'revert'));
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+UPDATE audit_log SET action = 'update' WHERE action = 'revert';
+
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS id SERIAL UNIQUE;
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+
+UPDATE auth SET is_admin = false WHERE is_admin IS NULL;
+ALTER TABLE auth ALTER COLUMN is_admin SET DEFAULT false;
+ALTER TABLE auth ALTER COLUMN is_admin SET NOT NULL;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+ALTER TABLE auth ALTER COLUMN is_admin DROP NOT NULL;
+ALTER TABLE auth ALTER COLUMN is_admin DROP DEFAULT;
+
+ALTER TABLE auth DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE auth DROP COLUMN IF EXISTS id;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,38 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS roles (
+    name TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role TEXT,
+    permission TEXT,
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+INSERT INTO roles(name) VALUES ('viewer'), ('editor'), ('admin') ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions(name) VALUES
+    ('film:read'), ('film:create'), ('film:update'), ('film:delete'),
+    ('actor:read'), ('actor:create'), ('actor:update'), ('actor:delete'),
+    ('genre:read'), ('genre:create'), ('genre:update'), ('genre:delete'),
+    ('audit:read'), ('user:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions(role, permission)
+SELECT 'viewer', name FROM permissions WHERE name IN ('film:read', 'actor:read', 'genre:read')
+UNION ALL
+SELECT 'editor', name FROM permissions WHERE name IN ('film:read', 'actor:read', 'genre:read', 'film:create', 'film:update', 'actor:create', 'actor:update', 'audit:read')
+UNION ALL
+SELECT 'admin', name FROM permissions
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'viewer' REFERENCES roles(name);
+UPDATE auth SET role = 'admin' WHERE is_admin;
+ALTER TABLE auth DROP COLUMN IF EXISTS is_admin;
+COMMIT;
\ No newline at end of file
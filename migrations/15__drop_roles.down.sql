@@ -0,0 +1,9 @@
+BEGIN;
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+UPDATE auth SET is_admin = true WHERE role = 'admin';
+ALTER TABLE auth DROP COLUMN IF EXISTS role;
+
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
+COMMIT;
\ No newline at end of file
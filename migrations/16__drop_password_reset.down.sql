@@ -0,0 +1,6 @@
+BEGIN;
+DROP INDEX IF EXISTS password_reset_tokens_login_idx;
+DROP TABLE IF EXISTS password_reset_tokens;
+
+ALTER TABLE auth DROP COLUMN IF EXISTS sessions_revoked_at;
+COMMIT;
\ No newline at end of file
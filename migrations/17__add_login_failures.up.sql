@@ -0,0 +1,11 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS login_failures (
+    kind TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    failures INT NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (kind, subject)
+);
+
+CREATE INDEX IF NOT EXISTS login_failures_last_failure_at_idx ON login_failures (last_failure_at);
+COMMIT;
\ No newline at end of file
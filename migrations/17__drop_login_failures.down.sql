@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS login_failures_last_failure_at_idx;
+DROP TABLE IF EXISTS login_failures;
+COMMIT;
\ No newline at end of file
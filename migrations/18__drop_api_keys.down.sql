@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS api_keys_name_idx;
+DROP TABLE IF EXISTS api_keys;
+COMMIT;
\ No newline at end of file
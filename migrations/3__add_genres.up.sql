@@ -0,0 +1,18 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS genres_name_idx ON genres (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS film_genre (
+    film_id INT,
+    genre_id INT,
+    PRIMARY KEY (film_id, genre_id),
+    FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS film_genre_genre_idx ON film_genre (genre_id);
+COMMIT;
\ No newline at end of file
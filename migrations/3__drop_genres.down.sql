@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS film_genre;
+
+DROP TABLE IF EXISTS genres;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,13 @@
+BEGIN;
+ALTER TABLE film_actor ADD COLUMN IF NOT EXISTS character_name TEXT;
+ALTER TABLE film_actor ADD COLUMN IF NOT EXISTS billing INT;
+ALTER TABLE film_actor ADD COLUMN IF NOT EXISTS role TEXT CHECK (role IN ('lead', 'supporting', 'cameo', 'voice'));
+
+UPDATE film_actor SET billing = numbered.position
+FROM (
+    SELECT actor_id, film_id, ROW_NUMBER() OVER (PARTITION BY film_id ORDER BY actor_id) AS position FROM film_actor
+) AS numbered
+WHERE film_actor.actor_id = numbered.actor_id AND film_actor.film_id = numbered.film_id;
+
+ALTER TABLE film_actor ALTER COLUMN billing SET NOT NULL;
+COMMIT;
\ No newline at end of file
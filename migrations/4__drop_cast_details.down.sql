@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE film_actor DROP COLUMN IF EXISTS role;
+ALTER TABLE film_actor DROP COLUMN IF EXISTS billing;
+ALTER TABLE film_actor DROP COLUMN IF EXISTS character_name;
+COMMIT;
\ No newline at end of file
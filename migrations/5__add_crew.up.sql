@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS film_crew (
+    film_id INT,
+    person_id INT,
+    job TEXT CHECK (job IN ('director', 'writer', 'producer', 'composer', 'cinematographer', 'editor')),
+    PRIMARY KEY (film_id, person_id, job),
+    FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+    FOREIGN KEY (person_id) REFERENCES actors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS film_crew_person_idx ON film_crew (person_id, job);
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE IF EXISTS film_crew;
+COMMIT;
\ No newline at end of file
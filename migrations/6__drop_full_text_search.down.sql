@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS films_search_idx;
+ALTER TABLE films DROP COLUMN IF EXISTS search_vector;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS films_title_trgm_idx ON films USING GIN(title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx ON actors USING GIN(name gin_trgm_ops);
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+DROP INDEX IF EXISTS films_title_trgm_idx;
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE films ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE actors DROP COLUMN IF EXISTS version;
+ALTER TABLE films DROP COLUMN IF EXISTS version;
+COMMIT;
\ No newline at end of file
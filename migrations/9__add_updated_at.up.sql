@@ -0,0 +1,25 @@
+BEGIN;
+ALTER TABLE films ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE film_actor ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE OR REPLACE FUNCTION set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER films_set_updated_at
+BEFORE UPDATE ON films
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER actors_set_updated_at
+BEFORE UPDATE ON actors
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER film_actor_set_updated_at
+BEFORE UPDATE ON film_actor
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,10 @@
+BEGIN;
+DROP TRIGGER IF EXISTS film_actor_set_updated_at ON film_actor;
+DROP TRIGGER IF EXISTS actors_set_updated_at ON actors;
+DROP TRIGGER IF EXISTS films_set_updated_at ON films;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE film_actor DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE actors DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE films DROP COLUMN IF EXISTS updated_at;
+COMMIT;
\ No newline at end of file
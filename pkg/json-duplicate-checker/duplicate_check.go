@@ -3,6 +3,7 @@ package jsonduplicatechecker
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	appErrors "github.com/PoorMercymain/filmoteka/errors"
 )
@@ -58,6 +59,22 @@ func checkArray(d *json.Decoder, path []string) error {
 			return err
 		}
 
+		if delim, ok := t.(json.Delim); ok {
+			elemPath := append(path, strconv.Itoa(index))
+			if delim == '{' {
+				err = checkObject(d, elemPath)
+			} else {
+				err = checkArray(d, elemPath)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			index++
+			continue
+		}
+
 		valStr := fmt.Sprintf("%v", t)
 
 		if values[valStr] {
@@ -88,4 +88,16 @@ func TestCheckDuplicatesInJSON(t *testing.T) {
 	d = json.NewDecoder(strings.NewReader("{\"test\":100, \"t\":11, \"test\":[\"1\", \"2\"]}"))
 	err = CheckDuplicatesInJSON(d, nil)
 	require.Error(t, err)
+
+	d = json.NewDecoder(strings.NewReader("{\"t\":[{\"a\":1}, {\"a\":1}, {\"a\":2, \"b\":[1, 2]}]}"))
+	err = CheckDuplicatesInJSON(d, nil)
+	require.NoError(t, err)
+
+	d = json.NewDecoder(strings.NewReader("{\"t\":[{\"a\":1}, {\"a\":1, \"a\":2}]}"))
+	err = CheckDuplicatesInJSON(d, nil)
+	require.Error(t, err)
+
+	d = json.NewDecoder(strings.NewReader("[[1, 2], [1, 1]]"))
+	err = CheckDuplicatesInJSON(d, nil)
+	require.Error(t, err)
 }
@@ -18,6 +18,19 @@ func ValidateJSONRequest(w http.ResponseWriter, r *http.Request, logErrPrefix st
 		return appErrors.ErrWrongRequestWithJSON
 	}
 
+	return validateBody(w, r, logErrPrefix)
+}
+
+func ValidateMergePatchRequest(w http.ResponseWriter, r *http.Request, logErrPrefix string) error {
+	if !jsonmimechecker.IsMergePatchContentTypeCorrect(r) {
+		httperrorwriter.WriteError(w, appErrors.ErrWrongMIME, http.StatusBadRequest, logErrPrefix)
+		return appErrors.ErrWrongRequestWithJSON
+	}
+
+	return validateBody(w, r, logErrPrefix)
+}
+
+func validateBody(w http.ResponseWriter, r *http.Request, logErrPrefix string) error {
 	bytesToCheck, err := io.ReadAll(r.Body)
 	if err != nil {
 		httperrorwriter.WriteError(w, err, http.StatusBadRequest, logErrPrefix)
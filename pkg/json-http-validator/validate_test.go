@@ -65,3 +65,28 @@ func TestValidate(t *testing.T) {
 	err = ValidateJSONRequest(trw, r, "")
 	require.Error(t, err)
 }
+
+func TestValidateMergePatch(t *testing.T) {
+	trw := testResponseWriter{}
+
+	r, err := http.NewRequest("PATCH", "/", strings.NewReader("{\"id\":0}"))
+	require.NoError(t, err)
+
+	r.Header.Set("Content-Type", "test")
+	err = ValidateMergePatchRequest(trw, r, "")
+	require.Error(t, err)
+
+	r, err = http.NewRequest("PATCH", "/", strings.NewReader("{\"id\":0,\"id\":null}"))
+	require.NoError(t, err)
+
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	err = ValidateMergePatchRequest(trw, r, "")
+	require.Error(t, err)
+
+	r, err = http.NewRequest("PATCH", "/", strings.NewReader("{\"id\":null}"))
+	require.NoError(t, err)
+
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	err = ValidateMergePatchRequest(trw, r, "")
+	require.NoError(t, err)
+}
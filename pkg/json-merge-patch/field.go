@@ -0,0 +1,22 @@
+package jsonmergepatch
+
+import "encoding/json"
+
+// Field is a member of a JSON Merge Patch (RFC 7396) document, it tells an absent member (Set is false)
+// from a member set to null (Null is true) and from a member with a value.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+
+	if string(data) == "null" {
+		f.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &f.Value)
+}
@@ -0,0 +1,32 @@
+package jsonmergepatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPatch struct {
+	Name   Field[string]  `json:"name"`
+	Rating Field[float32] `json:"rating"`
+	IDs    Field[[]int]   `json:"ids"`
+}
+
+func TestField(t *testing.T) {
+	var patch testPatch
+	err := json.Unmarshal([]byte(`{"name":"abc","rating":null}`), &patch)
+	require.NoError(t, err)
+
+	require.Equal(t, Field[string]{Set: true, Value: "abc"}, patch.Name)
+	require.Equal(t, Field[float32]{Set: true, Null: true}, patch.Rating)
+	require.Equal(t, Field[[]int]{}, patch.IDs)
+
+	patch = testPatch{}
+	err = json.Unmarshal([]byte(`{"ids":[1,2]}`), &patch)
+	require.NoError(t, err)
+	require.Equal(t, Field[[]int]{Set: true, Value: []int{1, 2}}, patch.IDs)
+
+	err = json.Unmarshal([]byte(`{"rating":"abc"}`), &patch)
+	require.Error(t, err)
+}
@@ -19,3 +19,13 @@ func IsJSONContentTypeCorrect(r *http.Request) bool {
 
 	return true
 }
+
+func IsMergePatchContentTypeCorrect(r *http.Request) bool {
+	for _, contentType := range r.Header.Values("Content-Type") {
+		if contentType == "application/merge-patch+json" {
+			return true
+		}
+	}
+
+	return IsJSONContentTypeCorrect(r)
+}
@@ -23,3 +23,23 @@ func TestIsJSONContentTypeCorrect(t *testing.T) {
 	isCorrect = IsJSONContentTypeCorrect(r)
 	require.False(t, isCorrect)
 }
+
+func TestIsMergePatchContentTypeCorrect(t *testing.T) {
+	r, err := http.NewRequest("PATCH", "", bytes.NewReader([]byte("")))
+	require.NoError(t, err)
+
+	isCorrect := IsMergePatchContentTypeCorrect(r)
+	require.False(t, isCorrect)
+
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	isCorrect = IsMergePatchContentTypeCorrect(r)
+	require.True(t, isCorrect)
+
+	r.Header.Set("Content-Type", "application/json")
+	isCorrect = IsMergePatchContentTypeCorrect(r)
+	require.True(t, isCorrect)
+
+	r.Header.Set("Content-Type", "text/plain")
+	isCorrect = IsMergePatchContentTypeCorrect(r)
+	require.False(t, isCorrect)
+}
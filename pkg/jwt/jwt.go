@@ -1,7 +1,11 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -11,15 +15,36 @@ import (
 
 type Claims struct {
 	*jwt.RegisteredClaims
-	IsAdmin bool `json:"isAdmin"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
 }
 
-func CreateJWT(isAdmin bool, signingKey []byte, expiresAt time.Time) (string, error) {
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func CreateJWT(login string, role string, permissions []string, signingKey []byte, expiresAt time.Time) (string, error) {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", fmt.Errorf("jwt.CreateJWT(): %w", err)
+	}
+
 	claims := &Claims{
 		RegisteredClaims: &jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(id),
+			Subject:   login,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
-		IsAdmin: isAdmin,
+		Role:        role,
+		Permissions: permissions,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -31,16 +56,31 @@ func CreateJWT(isAdmin bool, signingKey []byte, expiresAt time.Time) (string, er
 	return tokenString, nil
 }
 
-func CheckIsAdminInJWT(tokenString string, signingKey string) (bool, error) {
+// Tokens without a subject, an id or an expiration time are not accepted.
+func ParseJWT(tokenString string, signingKey string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(signingKey), nil
 	})
 
-	if err != nil || !token.Valid {
-		return false, fmt.Errorf("jwt.CheckIsAdminInJWT(): %w", appErrors.ErrTokenIsInvalid)
+	if err != nil || !token.Valid || claims.RegisteredClaims == nil || claims.Subject == "" || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("jwt.ParseJWT(): %w", appErrors.ErrTokenIsInvalid)
+	}
+
+	return claims, nil
+}
+
+func FromRequest(r *http.Request) (string, error) {
+	authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if authToken != "" {
+		return authToken, nil
+	}
+
+	cookie, err := r.Cookie("authToken")
+	if err != nil {
+		return "", fmt.Errorf("jwt.FromRequest(): %w", appErrors.ErrNoTokenProvided)
 	}
 
-	return claims.IsAdmin, nil
+	return cookie.Value, nil
 }
@@ -1,33 +1,69 @@
 package jwt
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/filmoteka/errors"
 )
 
 func TestJWT(t *testing.T) {
-	token, err := CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
+	token, err := CreateJWT("login", "viewer", []string{"film:read"}, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	_, err = CheckIsAdminInJWT(token, "abc")
+	_, err = ParseJWT(token, "abc")
 	require.Error(t, err)
 
-	isAdmin, err := CheckIsAdminInJWT(token, "")
+	claims, err := ParseJWT(token, "")
+	require.NoError(t, err)
+	require.Equal(t, "viewer", claims.Role)
+	require.True(t, claims.HasPermission("film:read"))
+	require.False(t, claims.HasPermission("film:update"))
+
+	token, err = CreateJWT("login", "admin", []string{"film:read", "film:update"}, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	claims, err = ParseJWT(token, "")
 	require.NoError(t, err)
-	require.Equal(t, false, isAdmin)
+	require.Equal(t, "admin", claims.Role)
+	require.True(t, claims.HasPermission("film:update"))
 
-	token, err = CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
+	expiredStr, err := CreateJWT("login", "viewer", nil, []byte(""), time.Now().Add(-1*time.Hour))
 	require.NoError(t, err)
 
-	isAdmin, err = CheckIsAdminInJWT(token, "")
+	_, err = ParseJWT(expiredStr, "")
+	require.Error(t, err)
+
+	claims, err = ParseJWT(token, "")
 	require.NoError(t, err)
-	require.Equal(t, true, isAdmin)
+	require.Equal(t, "login", claims.Subject)
 
-	expiredStr, err := CreateJWT(false, []byte(""), time.Now().Add(-1*time.Hour))
+	noSubjectStr, err := CreateJWT("", "viewer", nil, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	_, err = CheckIsAdminInJWT(expiredStr, "")
+	_, err = ParseJWT(noSubjectStr, "")
 	require.Error(t, err)
 }
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := FromRequest(r)
+	require.ErrorIs(t, err, appErrors.ErrNoTokenProvided)
+
+	r.AddCookie(&http.Cookie{Name: "authToken", Value: "cookie"})
+
+	token, err := FromRequest(r)
+	require.NoError(t, err)
+	require.Equal(t, "cookie", token)
+
+	r.Header.Set("Authorization", "Bearer header")
+
+	token, err = FromRequest(r)
+	require.NoError(t, err)
+	require.Equal(t, "header", token)
+}
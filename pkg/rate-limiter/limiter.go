@@ -0,0 +1,68 @@
+package ratelimiter
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket holding up to Requests tokens refilled per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l Limit) IsUnlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds()
+
+	if b.updatedAt.IsZero() {
+		b.tokens = capacity
+	} else if now.After(b.updatedAt) {
+		b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updatedAt).Seconds()*rate)
+	}
+	b.updatedAt = now
+
+	var result Result
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / rate)
+
+	return result
+}
+
+// A full bucket is the same as no bucket at all.
+func (b *bucket) fullAt(limit Limit) time.Time {
+	rate := float64(limit.Requests) / limit.Period.Seconds()
+	return b.updatedAt.Add(seconds((float64(limit.Requests) - b.tokens) / rate))
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
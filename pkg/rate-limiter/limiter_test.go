@@ -0,0 +1,48 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	limit := Limit{Requests: 2, Period: time.Second}
+	now := time.Date(2024, time.March, 16, 12, 0, 0, 0, time.UTC)
+
+	result, err := s.Take(context.Background(), "abc", limit, now)
+	require.NoError(t, err)
+	require.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 500 * time.Millisecond}, result)
+
+	result, err = s.Take(context.Background(), "abc", limit, now)
+	require.NoError(t, err)
+	require.Equal(t, Result{Allowed: true, Remaining: 0, Reset: time.Second}, result)
+
+	result, err = s.Take(context.Background(), "abc", limit, now)
+	require.NoError(t, err)
+	require.Equal(t, Result{Allowed: false, Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: time.Second}, result)
+
+	result, err = s.Take(context.Background(), "def", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	result, err = s.Take(context.Background(), "abc", limit, now.Add(500*time.Millisecond))
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Zero(t, result.Remaining)
+
+	result, err = s.Take(context.Background(), "abc", limit, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Remaining)
+
+	require.Len(t, s.buckets, 1)
+}
+
+func TestLimit(t *testing.T) {
+	require.True(t, Limit{}.IsUnlimited())
+	require.True(t, Limit{Requests: 1}.IsUnlimited())
+	require.False(t, Limit{Requests: 1, Period: time.Second}.IsUnlimited())
+}
@@ -0,0 +1,57 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	_ Store = (*MemoryStore)(nil)
+)
+
+const sweepInterval = time.Minute
+
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+// Every instance of the service limits the clients on its own with a MemoryStore.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	sweptAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+
+	return b.take(limit, now), nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !b.fullAt(b.limit).After(now) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.sweptAt = now
+}